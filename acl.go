@@ -5,8 +5,8 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/lib/pq/oid"
 	"github.com/streamtune/acl/change"
+	"github.com/streamtune/acl/oid"
 	"github.com/streamtune/acl/permission"
 	"github.com/streamtune/acl/sid"
 )
@@ -76,7 +76,7 @@ type Acl interface {
 	//
 	// This method must operate correctly even if the Acl only represents a subset of Sids. The caller is responsible
 	// for correctly handling the result if only a subset of Sids is represented.
-	IsGranted(permissions []permission.Permission, sids []sid.Sid, admin bool) (bool, error)
+	IsGranted(ctx context.Context, permissions []permission.Permission, sids []sid.Sid, admin bool, resource interface{}) (bool, error)
 
 	// For efficiency reasons an Acl may be loaded and not contain entries for every Sid in the system. If an Acl has
 	// been loaded and does not represent every Sid, all methods of the Acl can only be used within the limited scope of
@@ -99,7 +99,7 @@ type MutableAcl interface {
 	SetOwner(context.Context, sid.Sid) error
 
 	// Changes the value of entries inherits
-	SetEntriesInhriting(context.Context, bool) error
+	SetEntriesInheriting(context.Context, bool) error
 
 	// Change the parent object
 	SetParent(context.Context, Acl) error
@@ -119,7 +119,11 @@ type AuditableAcl interface {
 	MutableAcl
 
 	// Update auditing informations of an entry
-	UpdateAuditing(context.Context, int, bool, bool)
+	UpdateAuditing(context.Context, int, bool, bool) error
+
+	// UpdateScope attaches a runtime ScopeFn predicate (and the EnforcementLevel governing how Checker.Check reacts
+	// to it rejecting, plus the Sid allowed to override a SoftMandatory rejection) to the Ace at the given index.
+	UpdateScope(context.Context, int, ScopeFn, EnforcementLevel, sid.Sid) error
 }
 
 type acl struct {
@@ -224,11 +228,11 @@ func (acl *acl) IsEntriesInheriting() bool {
 }
 
 // IsGranted delegates to Granter
-func (acl *acl) IsGranted(permissions []permission.Permission, sids []sid.Sid, admin bool) (bool, error) {
+func (acl *acl) IsGranted(ctx context.Context, permissions []permission.Permission, sids []sid.Sid, admin bool, resource interface{}) (bool, error) {
 	if !acl.IsSidLoaded(sids) {
 		return false, errors.New("No all the requested Sid where loaded.")
 	}
-	return acl.checker.Check(acl, permissions, sids, admin)
+	return acl.checker.Check(ctx, acl, permissions, sids, admin, resource)
 }
 
 // IsSidLoaded check if all the provided Sids are laoded
@@ -308,6 +312,52 @@ func (acl *acl) UpdateAuditing(ctx context.Context, index int, succes, failure b
 	return nil
 }
 
+// UpdateScope attaches scope (and the enforcement level/override Sid governing how Checker.Check reacts once scope
+// rejects) to the Ace at index. Pass a nil scope to remove a previously attached predicate.
+func (acl *acl) UpdateScope(ctx context.Context, index int, scope ScopeFn, level EnforcementLevel, override sid.Sid) error {
+	if err := acl.authorizer.Authorize(ctx, acl, change.General); err != nil {
+		return err
+	}
+	if err := acl.verifyIndexExists(index); err != nil {
+		return err
+	}
+	ace, _ := acl.aces[index].(*accessControlEntry)
+	ace.setScope(scope, level, override)
+	return nil
+}
+
+// ScopeFn is a Sentinel-style runtime predicate bound to an Ace: besides matching on Permission and Sid, the entry
+// only applies once ScopeFn also passes for resource. A non-nil error return short-circuits the whole
+// Checker.Check call with that error, rather than merely treating the Ace as unmatched.
+type ScopeFn func(ctx context.Context, resource interface{}) (bool, error)
+
+// EnforcementLevel controls how Checker.Check reacts when an Ace's ScopeFn returns false. The zero value,
+// HardMandatory, always denies. Advisory never denies on a false result; it only affects auditing, so predicates
+// can be rolled out and observed before they start rejecting anything. SoftMandatory denies unless the requesting
+// Sids include the Ace's registered override Sid.
+type EnforcementLevel int
+
+const (
+	HardMandatory EnforcementLevel = iota
+	SoftMandatory
+	Advisory
+)
+
+// scopeRegistry lets ACE persistence layers reference a ScopeFn by name instead of storing a Go closure, e.g. when
+// an Ace is loaded from a database row that only has a scope_name column.
+var scopeRegistry = map[string]ScopeFn{}
+
+// RegisterScope makes fn available under name for persistence layers to look up via NamedScope.
+func RegisterScope(name string, fn ScopeFn) {
+	scopeRegistry[name] = fn
+}
+
+// NamedScope looks up a ScopeFn previously registered via RegisterScope.
+func NamedScope(name string) (ScopeFn, bool) {
+	fn, ok := scopeRegistry[name]
+	return fn, ok
+}
+
 // Ace represents an individual permission assignment within an Acl.
 //
 // Instances MUST be immutable, as they are returned by Acl and should not allow client modification.
@@ -327,20 +377,32 @@ type Ace interface {
 	// Indicates the permission is being granted to the relevant Sid. If false, indicates the permission is being
 	// revoked/blocked.
 	IsGranting() bool
+
+	// Scope returns the runtime predicate attached via AuditableAcl.UpdateScope, or nil if none was attached.
+	Scope() ScopeFn
+
+	// Enforcement reports how Checker.Check reacts when Scope rejects.
+	Enforcement() EnforcementLevel
+
+	// OverrideSid returns the Sid allowed to bypass a SoftMandatory Scope rejection, or nil if none was registered.
+	OverrideSid() sid.Sid
 }
 
 type accessControlEntry struct {
-	id         interface{}
-	acl        Acl
-	permission permission.Permission
-	sid        sid.Sid
-	granting   bool
-	succes     bool
-	failure    bool
+	id          interface{}
+	acl         Acl
+	permission  permission.Permission
+	sid         sid.Sid
+	granting    bool
+	succes      bool
+	failure     bool
+	scope       ScopeFn
+	enforcement EnforcementLevel
+	overrideSid sid.Sid
 }
 
 func newAccessControlEntry(id interface{}, acl Acl, sid sid.Sid, permission permission.Permission, granting, success, failure bool) *accessControlEntry {
-	return &accessControlEntry{id, acl, permission, sid, granting, success, failure}
+	return &accessControlEntry{id, acl, permission, sid, granting, success, failure, nil, HardMandatory, nil}
 }
 
 func (ace *accessControlEntry) GetAcl() Acl {
@@ -382,6 +444,24 @@ func (ace *accessControlEntry) setPermission(permission permission.Permission) {
 	ace.permission = permission
 }
 
+func (ace *accessControlEntry) Scope() ScopeFn {
+	return ace.scope
+}
+
+func (ace *accessControlEntry) Enforcement() EnforcementLevel {
+	return ace.enforcement
+}
+
+func (ace *accessControlEntry) OverrideSid() sid.Sid {
+	return ace.overrideSid
+}
+
+func (ace *accessControlEntry) setScope(scope ScopeFn, enforcement EnforcementLevel, overrideSid sid.Sid) {
+	ace.scope = scope
+	ace.enforcement = enforcement
+	ace.overrideSid = overrideSid
+}
+
 func (ace *accessControlEntry) String() string {
 	return fmt.Sprintf(
 		"AccessControlEntry[id: %s; granting: %t; sid: %s; permission: %s, auditSuccess: %t, auditFailure: %t]",
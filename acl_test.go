@@ -0,0 +1,44 @@
+package acl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+// auditAuthentication satisfies the structural Authentication interface expected by sid.Retrieve.
+type auditAuthentication struct{}
+
+func (auditAuthentication) GetPrincipal() string     { return "owner" }
+func (auditAuthentication) GetAuthorities() []string { return []string{"ROLE_AUDIT"} }
+
+// var _ AuditableAcl = (*acl)(nil) would not compile before this fix, since UpdateAuditing was declared
+// with no return value on the interface while *acl's implementation returns error.
+var _ AuditableAcl = (*acl)(nil)
+
+func TestAclUpdateAuditingThroughAuditableAcl(t *testing.T) {
+	auth, err := NewAuthorizer("ROLE_ADMIN", "ROLE_AUDIT", "ROLE_OWNERSHIP")
+	if err != nil {
+		t.Fatalf("NewAuthorizer: %v", err)
+	}
+	owner, err := sid.ForPrincipal("owner")
+	if err != nil {
+		t.Fatalf("sid.ForPrincipal: %v", err)
+	}
+	a, err := newAcl(1, nil, auth, DefaultChecker(), nil, nil, false, owner)
+	if err != nil {
+		t.Fatalf("newAcl: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), "Authentication", auditAuthentication{})
+	if err := a.InsertAce(ctx, 0, permission.ReadPermission, owner, true); err != nil {
+		t.Fatalf("InsertAce: %v", err)
+	}
+
+	var auditable AuditableAcl = a
+	if err := auditable.UpdateAuditing(ctx, 0, true, true); err != nil {
+		t.Fatalf("UpdateAuditing through AuditableAcl: %v", err)
+	}
+}
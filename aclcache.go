@@ -0,0 +1,175 @@
+package acl
+
+import (
+	"github.com/streamtune/acl/oid"
+)
+
+// Serializer lets an AclCache delegate storage to a pluggable backend (e.g. Redis, Memcached) instead of relying
+// purely on the built-in in-process maps, by converting a MutableAcl to and from a byte representation. AclCache
+// calls it, when set, alongside its own maps on every PutInCache, so an external-store-backed Cache can be grown
+// out of this hook later without changing AclCache's public API.
+type Serializer interface {
+	Marshal(MutableAcl) ([]byte, error)
+	Unmarshal([]byte) (MutableAcl, error)
+}
+
+// AclCache is a Cache that additionally evicts hierarchically: evicting an Acl also evicts every currently cached
+// Acl that (transitively) points to it as parent, since an ancestor change can alter what those descendants
+// inherit.
+type AclCache interface {
+	Cache
+}
+
+// hierarchicalCache is the in-memory AclCache implementation. It keeps a parent -> children index, rebuilt
+// incrementally on every PutInCache, so EvictFromCacheByOid/EvictFromCacheByID can walk straight to the affected
+// descendants instead of scanning the whole cache.
+type hierarchicalCache struct {
+	defaultCache
+	serializer Serializer
+	children   map[oid.Oid]map[oid.Oid]bool
+	serialized map[oid.Oid][]byte
+}
+
+// newHierarchicalCache creates a new in-process hierarchicalCache. serializer may be nil.
+func newHierarchicalCache(serializer Serializer) *hierarchicalCache {
+	cache := &hierarchicalCache{serializer: serializer, children: make(map[oid.Oid]map[oid.Oid]bool), serialized: make(map[oid.Oid][]byte)}
+	cache.idCache = make(map[interface{}]MutableAcl)
+	cache.oidCache = make(map[oid.Oid]MutableAcl)
+	return cache
+}
+
+// NewAclCache creates a new in-process AclCache. serializer may be nil, in which case the cache is purely
+// in-process; when set, it is additionally given every MutableAcl put in cache, so it can mirror entries to an
+// external store.
+func NewAclCache(serializer Serializer) AclCache {
+	return newHierarchicalCache(serializer)
+}
+
+// link records that child's parent is parent, so evicting parent later also evicts child. Callers must hold
+// cache.Lock().
+func (cache *hierarchicalCache) link(child, parent oid.Oid) {
+	if cache.children[parent] == nil {
+		cache.children[parent] = make(map[oid.Oid]bool)
+	}
+	cache.children[parent][child] = true
+}
+
+// unlink removes any parent link previously recorded for child. Callers must hold cache.Lock().
+func (cache *hierarchicalCache) unlink(child oid.Oid) {
+	for parent, kids := range cache.children {
+		if kids[child] {
+			delete(kids, child)
+			if len(kids) == 0 {
+				delete(cache.children, parent)
+			}
+		}
+	}
+}
+
+// collectDescendants returns every oid.Oid transitively reachable from o by following the children index. Callers
+// must hold cache.Lock() (or RLock()).
+func (cache *hierarchicalCache) collectDescendants(o oid.Oid) []oid.Oid {
+	var descendants []oid.Oid
+	queue := []oid.Oid{o}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for child := range cache.children[next] {
+			descendants = append(descendants, child)
+			queue = append(queue, child)
+		}
+	}
+	return descendants
+}
+
+// PutInCache stores acl and records its parent link, if any, for later hierarchical eviction. When serializer is
+// set, acl is also marshalled and the bytes kept alongside the in-process maps, for GetFromCacheByOid to Unmarshal
+// when the in-process maps lack the entry. evict and ClearCache drop the bytes together with the in-process entry.
+func (cache *hierarchicalCache) PutInCache(acl MutableAcl) {
+	cache.defaultCache.PutInCache(acl)
+	o := acl.GetIdentity()
+	if cache.serializer != nil {
+		if data, err := cache.serializer.Marshal(acl); err == nil {
+			cache.Lock()
+			cache.serialized[o] = data
+			cache.Unlock()
+		}
+	}
+	cache.Lock()
+	cache.unlink(o)
+	if parent := acl.GetParent(); parent != nil {
+		cache.link(o, parent.GetIdentity())
+	}
+	cache.Unlock()
+}
+
+// GetFromCacheByOid retrieves the in-process entry for o, falling back to unmarshalling the bytes recorded by the
+// last PutInCache when the in-process maps lack it. An Acl removed by evict or ClearCache is not returned, since its
+// bytes are removed with it.
+func (cache *hierarchicalCache) GetFromCacheByOid(o oid.Oid) (MutableAcl, bool) {
+	if found, ok := cache.defaultCache.GetFromCacheByOid(o); ok {
+		return found, true
+	}
+	if cache.serializer == nil {
+		return nil, false
+	}
+	cache.RLock()
+	data, ok := cache.serialized[o]
+	cache.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	acl, err := cache.serializer.Unmarshal(data)
+	if err != nil {
+		return nil, false
+	}
+	return acl, true
+}
+
+// EvictFromCacheByOid evicts o, and transitively every cached Acl that points to it as parent.
+func (cache *hierarchicalCache) EvictFromCacheByOid(o oid.Oid) {
+	cache.evict(o)
+}
+
+// EvictFromCacheByID evicts the Acl identified by id, and transitively every cached Acl that points to it as
+// parent.
+func (cache *hierarchicalCache) EvictFromCacheByID(id interface{}) {
+	found, ok := cache.defaultCache.GetFromCacheByID(id)
+	if !ok {
+		return
+	}
+	cache.evict(found.GetIdentity())
+}
+
+// evict removes o, and every descendant currently pointing to it (transitively) as parent, from both the
+// defaultCache maps and the children index.
+func (cache *hierarchicalCache) evict(o oid.Oid) {
+	cache.Lock()
+	descendants := cache.collectDescendants(o)
+	cache.Unlock()
+
+	cache.defaultCache.EvictFromCacheByOid(o)
+	for _, d := range descendants {
+		cache.defaultCache.EvictFromCacheByOid(d)
+	}
+
+	cache.Lock()
+	cache.unlink(o)
+	delete(cache.children, o)
+	delete(cache.serialized, o)
+	for _, d := range descendants {
+		cache.unlink(d)
+		delete(cache.children, d)
+		delete(cache.serialized, d)
+	}
+	cache.Unlock()
+}
+
+// ClearCache empties the defaultCache maps, the children index and any serialized bytes.
+func (cache *hierarchicalCache) ClearCache() {
+	cache.defaultCache.ClearCache()
+	cache.Lock()
+	cache.children = make(map[oid.Oid]map[oid.Oid]bool)
+	cache.serialized = make(map[oid.Oid][]byte)
+	cache.Unlock()
+}
@@ -0,0 +1,170 @@
+package acl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// AuditEvent captures everything about one authorization decision worth recording, so a pluggable Auditor doesn't
+// need to re-derive context (who, what, why) from a bare granted/denied bool and a LegacyAce.
+type AuditEvent struct {
+	Granted     bool
+	LegacyAce   LegacyAce
+	AccessorID  string
+	Oid         Oid
+	Permission  Permission
+	Enforcement EnforcementLevel
+	// Reason distinguishes the circumstances under which the decision was reached, e.g. "granted", "denied",
+	// "advisory-deny" or "override".
+	Reason string
+}
+
+// Auditor receives one AuditEvent for every ACE that participates in an IsGranted decision, including ones reached
+// only via parent-ACL inheritance.
+type Auditor interface {
+	Audit(AuditEvent)
+}
+
+// consoleAuditor prints AuditEvents in a human-readable form, honouring each LegacyAce's IsAuditSuccess/IsAuditFailure
+// preference the same way the original fmt.Printf-based DefaultLogger did.
+type consoleAuditor struct{}
+
+// Console returns an Auditor that prints AuditEvents to stdout.
+func Console() Auditor {
+	return consoleAuditor{}
+}
+
+func (consoleAuditor) Audit(e AuditEvent) {
+	auditable, ok := e.LegacyAce.(LegacyAuditableAce)
+	if !ok {
+		return
+	}
+	if e.Granted && auditable.IsAuditSuccess() {
+		fmt.Printf("Granted due to ACE %s (accessor: %s, reason: %s)\n", e.LegacyAce, e.AccessorID, e.Reason)
+	} else if !e.Granted && auditable.IsAuditFailure() {
+		fmt.Printf("Denied due to ACE %s (accessor: %s, reason: %s)\n", e.LegacyAce, e.AccessorID, e.Reason)
+	}
+}
+
+// jsonAuditor writes one JSON object per AuditEvent to w, newline-delimited.
+type jsonAuditor struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// JSON returns an Auditor that writes AuditEvents to w as newline-delimited JSON.
+func JSON(w io.Writer) Auditor {
+	return &jsonAuditor{w: w}
+}
+
+func (j *jsonAuditor) Audit(e AuditEvent) {
+	record := struct {
+		Granted     bool             `json:"granted"`
+		AccessorID  string           `json:"accessor_id,omitempty"`
+		Permission  Permission       `json:"permission"`
+		Enforcement EnforcementLevel `json:"enforcement"`
+		Reason      string           `json:"reason"`
+	}{e.Granted, e.AccessorID, e.Permission, e.Enforcement, e.Reason}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_ = json.NewEncoder(j.w).Encode(record)
+}
+
+// noopAuditor discards every AuditEvent. Use it for admin-mode paths, where decisions must bypass auditing
+// entirely rather than merely suppress some of it.
+type noopAuditor struct{}
+
+// Noop returns an Auditor that discards every AuditEvent.
+func Noop() Auditor {
+	return noopAuditor{}
+}
+
+func (noopAuditor) Audit(AuditEvent) {}
+
+// MultiAuditor fans a single AuditEvent out to N sinks concurrently. Each sink gets its own bounded buffer so a
+// slow sink cannot block IsGranted, or any other sink; events submitted while a sink's buffer is full are dropped
+// and counted rather than blocking.
+type MultiAuditor struct {
+	sinks   []Auditor
+	queues  []chan AuditEvent
+	dropped []uint64
+}
+
+// NewMultiAuditor starts one fan-out goroutine per sink, each reading from a channel of the given buffer size.
+func NewMultiAuditor(buffer int, sinks ...Auditor) *MultiAuditor {
+	m := &MultiAuditor{
+		sinks:   sinks,
+		queues:  make([]chan AuditEvent, len(sinks)),
+		dropped: make([]uint64, len(sinks)),
+	}
+	for i, sink := range sinks {
+		q := make(chan AuditEvent, buffer)
+		m.queues[i] = q
+		go func(sink Auditor, q chan AuditEvent) {
+			for e := range q {
+				sink.Audit(e)
+			}
+		}(sink, q)
+	}
+	return m
+}
+
+// Audit enqueues e on every sink's buffer, dropping it for sinks whose buffer is currently full.
+func (m *MultiAuditor) Audit(e AuditEvent) {
+	for i, q := range m.queues {
+		select {
+		case q <- e:
+		default:
+			atomic.AddUint64(&m.dropped[i], 1)
+		}
+	}
+}
+
+// Dropped reports how many events have been dropped for the sink at the given index, e.g. for a
+// dropped_audit_events metric.
+func (m *MultiAuditor) Dropped(sink int) uint64 {
+	return atomic.LoadUint64(&m.dropped[sink])
+}
+
+// MetricsAuditor wraps another Auditor and maintains acl_decisions_total{result,reason}-style counters, so
+// operators can alert on denial spikes without this package depending on a particular metrics client.
+type MetricsAuditor struct {
+	next   Auditor
+	mu     sync.Mutex
+	counts map[string]map[string]uint64 // result ("granted"/"denied") -> reason -> count
+}
+
+// NewMetricsAuditor wraps next, counting every AuditEvent before forwarding it. next may be nil, in which case
+// events are counted and then discarded.
+func NewMetricsAuditor(next Auditor) *MetricsAuditor {
+	if next == nil {
+		next = Noop()
+	}
+	return &MetricsAuditor{next: next, counts: make(map[string]map[string]uint64)}
+}
+
+func (m *MetricsAuditor) Audit(e AuditEvent) {
+	result := "denied"
+	if e.Granted {
+		result = "granted"
+	}
+	m.mu.Lock()
+	byReason, ok := m.counts[result]
+	if !ok {
+		byReason = make(map[string]uint64)
+		m.counts[result] = byReason
+	}
+	byReason[e.Reason]++
+	m.mu.Unlock()
+	m.next.Audit(e)
+}
+
+// Count returns acl_decisions_total{result=result,reason=reason} as observed so far.
+func (m *MetricsAuditor) Count(result, reason string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counts[result][reason]
+}
@@ -36,3 +36,15 @@ func Console() Auditor {
 func Default() Auditor {
 	return Console()
 }
+
+// noopAuditor discards every audit call. Use it for admin-mode checks, which must bypass auditing entirely rather
+// than have each call site special-case suppressing it.
+type noopAuditor struct{}
+
+// Audit discards granted/ace.
+func (noopAuditor) Audit(bool, Auditable) {}
+
+// Noop returns an Auditor that discards every audit call.
+func Noop() Auditor {
+	return noopAuditor{}
+}
@@ -22,16 +22,39 @@ type Authorizer interface {
 // - Has BasePermission Adminnistration permission (as defined by the ACL).
 type authorizer struct {
 	authorizations map[change.Type]sid.Sid
+	identity       IdentityProvider
+	resolve        PolicyResolver
+	config         *Config
 }
 
-// Authorize perform the security check for the given change type
+// Authorize perform the security check for the given change type. Identity resolution ("who is the principal") is
+// delegated to a.identity, and mapping that Identity to the Sid instances of the current principal is delegated to
+// a.resolve, so callers only need to reach into context.Context for the ACL walk itself, not for their token scheme.
 func (a *authorizer) Authorize(ctx context.Context, acl Acl, chg change.Type) error {
 	if ctx == nil {
 		return errors.New("Context is required to operate on acl.")
 	}
-	sids, err := sid.Retrieve(ctx)
-	if err != nil {
-		return err
+	var sids []sid.Sid
+	if a.identity != nil {
+		identity, err := a.identity.ResolveIdentity(ctx)
+		if err != nil {
+			return err
+		}
+		resolve := a.resolve
+		if resolve == nil {
+			resolve = DefaultPolicyResolver
+		}
+		resolved, err := resolve(identity)
+		if err != nil {
+			return err
+		}
+		sids = resolved
+	} else {
+		resolved, err := sid.Retrieve(ctx)
+		if err != nil {
+			return err
+		}
+		sids = resolved
 	}
 	currentUser := sids[0]
 	if currentUser.Equals(acl.GetOwner()) && (chg == change.General || chg == change.Ownership) {
@@ -40,7 +63,7 @@ func (a *authorizer) Authorize(ctx context.Context, acl Acl, chg change.Type) er
 	// Not authorized by ACL ownership; try via administrtive permissions
 	authority, ok := a.authorizations[chg]
 	if !ok {
-		return errors.New("Unsupported change type")
+		return &PermissionDeniedError{Accessor: currentUser, Permission: chg, Oid: acl.GetIdentity(), Cause: "unsupported change type"}
 	}
 	// Iterate the principal's authorities to determine right
 	for _, v := range sids {
@@ -49,12 +72,12 @@ func (a *authorizer) Authorize(ctx context.Context, acl Acl, chg change.Type) er
 		}
 	}
 	// Try to get permissions via ACEs within the ACL
-	permissions := []permission.Permission{permission.Administration}
-	if ok, err := acl.IsGranted(permissions, sids, false); err != nil && ok {
+	permissions := []permission.Permission{permission.AdministrationPermission}
+	if ok, err := acl.IsGranted(ctx, permissions, sids, false, nil); err == nil && ok {
 		return nil
 	}
 
-	return errors.New("Principal does not have required ACL permissions to perform required operation.")
+	return &PermissionDeniedError{Accessor: currentUser, Permission: chg, Oid: acl.GetIdentity()}
 }
 
 // NewAuthorizer will create a new default AuthorizationStrategy
@@ -82,3 +105,22 @@ func NewAuthorizer(general, auditing, ownership string) (Authorizer, error) {
 func SimpleAuthorizer(authority string) (Authorizer, error) {
 	return NewAuthorizer(authority, authority, authority)
 }
+
+// WithConfig stores cfg on a, returning the same Authorizer for chaining. Authorize does not read the stored Config,
+// so it has no effect on authorization decisions; wildcard matching is decided by the Acl's own IsGranted.
+func WithConfig(a Authorizer, cfg *Config) Authorizer {
+	if impl, ok := a.(*authorizer); ok {
+		impl.config = cfg
+	}
+	return a
+}
+
+// WithIdentityProvider reconfigures the receiver to resolve the current principal via provider/resolver instead of
+// sid.Retrieve, returning the same Authorizer for chaining. A nil resolver falls back to DefaultPolicyResolver.
+func WithIdentityProvider(a Authorizer, provider IdentityProvider, resolver PolicyResolver) Authorizer {
+	if impl, ok := a.(*authorizer); ok {
+		impl.identity = provider
+		impl.resolve = resolver
+	}
+	return a
+}
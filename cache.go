@@ -82,3 +82,113 @@ func (cache *defaultCache) ClearCache() {
 	cache.oidCache = make(map[oid.Oid]MutableAcl)
 	cache.Unlock()
 }
+
+// CancelFunc stops a subscription created by WatchableCache.Subscribe. Calling it closes the associated channel and
+// releases any resources held for that subscriber; it is safe to call more than once.
+type CancelFunc func()
+
+// WatchableCache extends Cache with the ability to watch an individual Oid for changes. A MutableService that
+// updates or deletes an Acl should publish an invalidation through the cache so that both its own entries are
+// evicted and any subscriber is notified, eliminating the stale-permission window after a role or ACE change.
+// Long-lived request handlers (e.g. streaming RPCs) can use Subscribe to re-authorize whenever policy shifts
+// underneath them, similar to blocking-query patterns used by distributed ACL systems.
+type WatchableCache interface {
+	Cache
+
+	// Subscribe returns a channel that receives the new MutableAcl every time o is put in cache and nil every time
+	// it is evicted, and a CancelFunc that stops the subscription and closes the channel.
+	Subscribe(o oid.Oid) (<-chan MutableAcl, CancelFunc)
+}
+
+// CacheReplicator is a hook that lets a WatchableCache bridge invalidation events to other processes, e.g. by
+// publishing them to Redis pub/sub or NATS in a multi-node deployment. It is consulted in addition to, not instead
+// of, the in-process subscribers managed by WatchableCache.
+type CacheReplicator interface {
+	// Publish is called whenever acl is put in cache or evicted (in which case acl is nil).
+	Publish(o oid.Oid, acl MutableAcl) error
+}
+
+type watchableCache struct {
+	defaultCache
+	replicator  CacheReplicator
+	subscribers map[oid.Oid]map[int]chan MutableAcl
+	nextID      int
+}
+
+// newWatchableCache creates a new in-process WatchableCache. replicator may be nil, in which case invalidations are
+// only fanned out to local subscribers.
+func newWatchableCache(replicator CacheReplicator) *watchableCache {
+	cache := &watchableCache{replicator: replicator, subscribers: make(map[oid.Oid]map[int]chan MutableAcl)}
+	cache.idCache = make(map[interface{}]MutableAcl)
+	cache.oidCache = make(map[oid.Oid]MutableAcl)
+	return cache
+}
+
+// NewWatchableCache creates a new in-process WatchableCache, optionally replicating invalidations to other nodes
+// through replicator (may be nil).
+func NewWatchableCache(replicator CacheReplicator) WatchableCache {
+	return newWatchableCache(replicator)
+}
+
+func (cache *watchableCache) publish(o oid.Oid, acl MutableAcl) {
+	if cache.replicator != nil {
+		cache.replicator.Publish(o, acl)
+	}
+	// The send loop must stay under the read lock for its entire duration, not just long enough to snapshot
+	// cache.subscribers[o]: Subscribe's cancel closure takes the write lock to delete from that same map before it
+	// closes the channel, so releasing the read lock early let a concurrent cancel race a still-in-flight range
+	// (concurrent map access) or close a channel publish was about to send on (send on closed channel panic).
+	cache.RLock()
+	for _, ch := range cache.subscribers[o] {
+		select {
+		case ch <- acl:
+		default:
+			// Slow subscriber: drop rather than block the writer.
+		}
+	}
+	cache.RUnlock()
+}
+
+func (cache *watchableCache) PutInCache(acl MutableAcl) {
+	cache.defaultCache.PutInCache(acl)
+	cache.publish(acl.GetIdentity(), acl)
+}
+
+func (cache *watchableCache) EvictFromCacheByID(id interface{}) {
+	acl, ok := cache.defaultCache.GetFromCacheByID(id)
+	cache.defaultCache.EvictFromCacheByID(id)
+	if ok {
+		cache.publish(acl.GetIdentity(), nil)
+	}
+}
+
+func (cache *watchableCache) EvictFromCacheByOid(o oid.Oid) {
+	cache.defaultCache.EvictFromCacheByOid(o)
+	cache.publish(o, nil)
+}
+
+func (cache *watchableCache) Subscribe(o oid.Oid) (<-chan MutableAcl, CancelFunc) {
+	ch := make(chan MutableAcl, 1)
+	cache.Lock()
+	if cache.subscribers[o] == nil {
+		cache.subscribers[o] = make(map[int]chan MutableAcl)
+	}
+	id := cache.nextID
+	cache.nextID++
+	cache.subscribers[o][id] = ch
+	cache.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			cache.Lock()
+			delete(cache.subscribers[o], id)
+			if len(cache.subscribers[o]) == 0 {
+				delete(cache.subscribers, o)
+			}
+			cache.Unlock()
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
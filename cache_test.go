@@ -0,0 +1,50 @@
+package acl
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/streamtune/acl/oid"
+)
+
+func TestWatchableCachePublishSubscribeCancelConcurrent(t *testing.T) {
+	cache := newWatchableCache(nil)
+	o, err := oid.Generate(1, "Doc")
+	if err != nil {
+		t.Fatalf("oid.Generate: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, cancel := cache.Subscribe(o)
+			cancel()
+		}()
+		go func() {
+			defer wg.Done()
+			cache.publish(o, nil)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWatchableCacheSubscribeReceivesPublish(t *testing.T) {
+	cache := newWatchableCache(nil)
+	o, err := oid.Generate(1, "Doc")
+	if err != nil {
+		t.Fatalf("oid.Generate: %v", err)
+	}
+
+	ch, cancel := cache.Subscribe(o)
+	defer cancel()
+
+	cache.publish(o, nil)
+
+	select {
+	case <-ch:
+	default:
+		t.Fatal("expected the subscriber channel to receive the published invalidation")
+	}
+}
@@ -7,7 +7,7 @@ type Type int
 // Auditing is a change of auditing behavior
 // General is any other type of change
 const (
-	Ownership Tyep = iota
+	Ownership Type = iota
 	Auditing
 	General
 )
@@ -1,76 +1,195 @@
 package acl
 
 import (
+	"context"
 	"errors"
 
 	"github.com/streamtune/acl/audit"
 	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/policy"
 	"github.com/streamtune/acl/sid"
 )
 
 // Checker is the interface used to check permissions
 type Checker interface {
-	Check(Acl, []permission.Permission, []sid.Sid, bool) (bool, error)
+	Check(ctx context.Context, acl Acl, permissions []permission.Permission, sids []sid.Sid, admin bool, resource interface{}) (bool, error)
 }
 
 type checker struct {
-	auditor audit.Auditor
+	auditor  audit.Auditor
+	config   *Config
+	policies *policy.Authorizer
 }
 
-// Check will perform the check the provided acl for given permissions and Sid
-func (c *checker) Check(acl Acl, permissions []permission.Permission, sids []sid.Sid, admin bool) (bool, error) {
+// WithPolicies reconfigures c to first consult policies for the requesting Sids before falling back to per-ACE
+// evaluation: Check calls policies.Authorize(sids, acl.GetIdentity(), p) for each requested Permission p and grants
+// immediately on a match, only walking GetEntries() when none applies. Returns c unchanged if it is not a *checker.
+func WithPolicies(c Checker, policies *policy.Authorizer) Checker {
+	if impl, ok := c.(*checker); ok {
+		impl.policies = policies
+	}
+	return c
+}
+
+// wildcardName returns the Sid name c treats as "matches anyone", defaulting to Config's "*" when c has no Config.
+func (c *checker) wildcardName() string {
+	if c.config != nil && c.config.WildcardName != "" {
+		return c.config.WildcardName
+	}
+	return "*"
+}
+
+// wildcardPermission returns the Permission c treats as "matches any requested permission".
+func (c *checker) wildcardPermission() permission.Permission {
+	if c.config != nil {
+		return permission.Permission(c.config.WildcardPermission)
+	}
+	return permission.Wildcard()
+}
+
+// matchingAce scans aces for the entry that decides p for sid, preferring an exact, non-wildcard match over one
+// reached only through c's wildcard Sid/Permission. The bool result reports whether the match was only a wildcard
+// one, so the caller can let a later, more specific ACE still win. Precedence is enforced regardless of Ace order:
+// an exact Sid match is returned the moment it is found, so an explicit grant on a concrete Sid always beats a
+// "deny" ACE bound to the wildcard Sid, and vice versa an explicit deny on the concrete Sid always beats a
+// wildcard grant.
+func (c *checker) matchingAce(aces []Ace, p permission.Permission, s sid.Sid) (Ace, bool) {
+	var wildcardMatch Ace
+	wildcardName := c.wildcardName()
+	for _, ace := range aces {
+		if !ace.GetPermission().Match(p) && ace.GetPermission() != c.wildcardPermission() {
+			continue
+		}
+		// A wildcard-named Ace's Sid reports Equals(s) == true for every s, which would otherwise make it look
+		// like an exact match and let it return immediately regardless of where it sits relative to a concrete
+		// Sid's Ace. Recognize it by name first so it can only ever be returned as the deferred wildcardMatch.
+		if ace.GetSid().Name() == wildcardName {
+			if wildcardMatch == nil {
+				wildcardMatch = ace
+			}
+			continue
+		}
+		if ace.GetSid().Equals(s) {
+			return ace, false
+		}
+	}
+	return wildcardMatch, wildcardMatch != nil
+}
+
+// Check will perform the check the provided acl for given permissions and Sid. resource is passed through to any
+// matching Ace's ScopeFn, letting a predicate inspect the concrete object being accessed.
+func (c *checker) Check(ctx context.Context, acl Acl, permissions []permission.Permission, sids []sid.Sid, admin bool, resource interface{}) (bool, error) {
+	auditor := c.auditor
+	if admin {
+		// Admin-mode decisions must bypass auditing entirely, rather than have every Audit call below special-case
+		// suppressing it.
+		auditor = audit.Noop()
+	}
+	if c.policies != nil {
+		for _, p := range permissions {
+			if c.policies.Authorize(sids, acl.GetIdentity(), p) {
+				return true, nil
+			}
+		}
+	}
 	aces := acl.GetEntries()
 	var firstRejection Ace
 	for _, p := range permissions {
-		for _, sid := range sids {
-			// Attempt to find the exact match for this permission mask and SID
-			scanNextSid := false
-			for _, ace := range aces {
-				if ace.GetPermission().Match(p) && ace.GetSid().Equals(sid) {
-					// Found a matching ACE, so its authorization decision will prevail
-					if ace.IsGranting() {
-						// Success
-						if auditable, ok := ace.(audit.Auditable); ok && !admin {
-							c.auditor.Audit(true, auditable)
+		for _, s := range sids {
+			ace, wildcard := c.matchingAce(aces, p, s)
+			if ace == nil {
+				continue
+			}
+			// A non-nil ScopeFn must also pass for this Ace to apply. Its EnforcementLevel governs what a false
+			// result actually means: Advisory never denies (it only gets audited), SoftMandatory denies unless the
+			// requesting Sids include the Ace's override Sid, and HardMandatory (the zero value) always denies.
+			if scope := ace.Scope(); scope != nil {
+				passed, err := scope(ctx, resource)
+				if err != nil {
+					return false, err
+				}
+				if !passed {
+					reject := false
+					switch ace.Enforcement() {
+					case Advisory:
+						// Advisory predicates never deny; audit the miss and fall through to the Ace's own
+						// grant/deny state as if Scope had passed.
+						if auditable, ok := ace.(audit.Auditable); ok {
+							auditor.Audit(false, auditable)
 						}
-						return true, nil
+					case SoftMandatory:
+						reject = !sidsInclude(sids, ace.OverrideSid())
+					default:
+						// HardMandatory (the zero value): always deny.
+						reject = true
 					}
-					// Failure for this permission, so stop search. We will see if they have a different permission
-					// (this permission is 100% rejected for this SID)
-					if firstRejection == nil {
-						// Store first rejection for auditing purposes
-						firstRejection = ace
+					if reject {
+						if firstRejection == nil || !wildcard {
+							firstRejection = ace
+						}
+						break
 					}
-					scanNextSid = false // Helps break the loop
-					break
 				}
 			}
-			if !scanNextSid {
-				break
+			// Found a matching ACE, so its authorization decision will prevail
+			if ace.IsGranting() {
+				// Success
+				if auditable, ok := ace.(audit.Auditable); ok {
+					auditor.Audit(true, auditable)
+				}
+				return true, nil
+			}
+			// Failure for this permission, so stop search. We will see if they have a different permission (this
+			// permission is 100% rejected for this SID) - unless the only match was a wildcard entry, in which case
+			// a more specific ACE elsewhere in the ACL should still get a chance to grant.
+			if firstRejection == nil || !wildcard {
+				firstRejection = ace
 			}
+			break
 		}
 	}
 	if firstRejection != nil {
 		// We found an ACE to reject the request at this point, as no other ACEs where found that granted a different
 		// permission
-		if auditable, ok := firstRejection.(audit.Auditable); ok && !admin {
-			c.auditor.Audit(false, auditable)
+		if auditable, ok := firstRejection.(audit.Auditable); ok {
+			auditor.Audit(false, auditable)
 		}
 	}
 
 	// No matches have been found so far
 	if parent := acl.GetParent(); parent != nil && acl.IsEntriesInheriting() {
-		return parent.IsGranted(permissions, sids, admin)
+		return parent.IsGranted(ctx, permissions, sids, admin, resource)
 	}
 	// We either have no parent or we're the uppermost parent
 	return false, errors.New("No entry found")
 }
 
+// sidsInclude reports whether target is present among sids. A nil target (no override Sid registered for the Ace)
+// never matches, so an unconfigured SoftMandatory Ace behaves exactly like HardMandatory once rejected.
+func sidsInclude(sids []sid.Sid, target sid.Sid) bool {
+	if target == nil {
+		return false
+	}
+	for _, s := range sids {
+		if s.Equals(target) {
+			return true
+		}
+	}
+	return false
+}
+
 // NewChecker will create a new default permission checker
 func NewChecker(auditor audit.Auditor) Checker {
 	return &checker{auditor: auditor}
 }
 
+// NewCheckerWithConfig is like NewChecker but lets the caller override the wildcard sentinel Sid/Permission
+// consulted when matching ACEs (see Config.WildcardName/WildcardPermission), for deployments that want a value
+// other than "*"/AnyPermission.
+func NewCheckerWithConfig(auditor audit.Auditor, config *Config) Checker {
+	return &checker{auditor: auditor, config: config}
+}
+
 // DefaultChecker will return the default checker initialized with default Auditor
 func DefaultChecker() Checker {
 	return NewChecker(audit.Default())
@@ -0,0 +1,131 @@
+package acl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/policy"
+	"github.com/streamtune/acl/sid"
+)
+
+// stubAcl is a minimal Acl implementation used to exercise Checker.Check without pulling in the full acl/authorizer
+// machinery.
+type stubAcl struct {
+	identity oid.Oid
+	owner    sid.Sid
+	parent   Acl
+	inherits bool
+	aces     []Ace
+}
+
+func (s *stubAcl) GetEntries() []Ace               { return s.aces }
+func (s *stubAcl) GetIdentity() oid.Oid            { return s.identity }
+func (s *stubAcl) GetOwner() sid.Sid               { return s.owner }
+func (s *stubAcl) GetParent() Acl                  { return s.parent }
+func (s *stubAcl) IsEntriesInheriting() bool       { return s.inherits }
+func (s *stubAcl) IsSidLoaded(sids []sid.Sid) bool { return true }
+func (s *stubAcl) IsGranted(ctx context.Context, perms []permission.Permission, sids []sid.Sid, admin bool, resource interface{}) (bool, error) {
+	return DefaultChecker().Check(ctx, s, perms, sids, admin, resource)
+}
+
+func mustOid(t *testing.T) oid.Oid {
+	t.Helper()
+	o, err := oid.Generate(1, "Doc")
+	if err != nil {
+		t.Fatalf("oid.Generate: %v", err)
+	}
+	return o
+}
+
+func mustSid(t *testing.T, name string) sid.Sid {
+	t.Helper()
+	s, err := sid.ForPrincipal(name)
+	if err != nil {
+		t.Fatalf("sid.ForPrincipal: %v", err)
+	}
+	return s
+}
+
+// TestCheckerWildcardGrant verifies that an Ace bound to sid.WildcardSid grants access to any requesting Sid.
+func TestCheckerWildcardGrant(t *testing.T) {
+	alice := mustSid(t, "alice")
+	acl := &stubAcl{
+		identity: mustOid(t),
+		aces:     []Ace{newAccessControlEntry(1, nil, sid.WildcardSid, permission.ReadPermission, true, false, false)},
+	}
+	granted, err := DefaultChecker().Check(context.Background(), acl, []permission.Permission{permission.ReadPermission}, []sid.Sid{alice}, false, nil)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected wildcard Ace to grant access to an unlisted Sid")
+	}
+}
+
+// TestCheckerExplicitDenyBeatsWildcardGrant verifies the least-specific-loses precedence rule: an explicit deny ACE
+// on the concrete requesting Sid must win over an earlier, wildcard-granting ACE.
+func TestCheckerExplicitDenyBeatsWildcardGrant(t *testing.T) {
+	alice := mustSid(t, "alice")
+	acl := &stubAcl{
+		identity: mustOid(t),
+		aces: []Ace{
+			newAccessControlEntry(1, nil, sid.WildcardSid, permission.ReadPermission, true, false, false),
+			newAccessControlEntry(2, nil, alice, permission.ReadPermission, false, false, false),
+		},
+	}
+	// A rejecting Ace with no parent to fall back to surfaces as (false, non-nil error), same as
+	// DefaultPermissionGrantingStrategy.IsGranted's PermissionDeniedError convention - only granted matters here.
+	granted, _ := DefaultChecker().Check(context.Background(), acl, []permission.Permission{permission.ReadPermission}, []sid.Sid{alice}, false, nil)
+	if granted {
+		t.Fatal("expected the explicit deny on alice to beat the wildcard grant")
+	}
+}
+
+// TestCheckerExplicitGrantBeatsWildcardDeny verifies the same precedence the other way around: an explicit grant on
+// the concrete Sid must win over an earlier, wildcard-denying ACE.
+func TestCheckerExplicitGrantBeatsWildcardDeny(t *testing.T) {
+	alice := mustSid(t, "alice")
+	acl := &stubAcl{
+		identity: mustOid(t),
+		aces: []Ace{
+			newAccessControlEntry(1, nil, sid.WildcardSid, permission.ReadPermission, false, false, false),
+			newAccessControlEntry(2, nil, alice, permission.ReadPermission, true, false, false),
+		},
+	}
+	granted, err := DefaultChecker().Check(context.Background(), acl, []permission.Permission{permission.ReadPermission}, []sid.Sid{alice}, false, nil)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected the explicit grant on alice to beat the wildcard deny")
+	}
+}
+
+// TestCheckerWithPoliciesShortCircuitsBeforeAces verifies that WithPolicies makes Check consult the policy.Authorizer
+// first, granting access even when the Acl carries no matching Ace at all.
+func TestCheckerWithPoliciesShortCircuitsBeforeAces(t *testing.T) {
+	alice := mustSid(t, "alice")
+	o := mustOid(t)
+
+	p, err := policy.Compile(policy.Document{Objects: []policy.ObjectGrant{
+		{Type: o.Type(), Name: "*", Permissions: []string{"read"}},
+	}})
+	if err != nil {
+		t.Fatalf("policy.Compile: %v", err)
+	}
+	authorizer := policy.NewAuthorizer()
+	authorizer.Grant(alice.Name(), p)
+
+	checker := WithPolicies(DefaultChecker(), authorizer)
+	acl := &stubAcl{identity: o} // no Aces at all: only the Policy can grant this
+
+	granted, err := checker.Check(context.Background(), acl, []permission.Permission{permission.ReadPermission}, []sid.Sid{alice}, false, nil)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected WithPolicies to grant access via the compiled Policy despite there being no matching Ace")
+	}
+}
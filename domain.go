@@ -1,75 +1,130 @@
 package acl
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"reflect"
 )
 
-// DefaultLogger is the default logger implementation
-func DefaultLogger(granted bool, ace Ace) {
-	if auditable, ok := ace.(AuditableAce); ok {
-		if granted && auditable.IsAuditSuccess() {
-			fmt.Printf("Granted due to ACE %s", ace)
-		} else if !granted && auditable.IsAuditFailure() {
-			fmt.Printf("Denied due to ACE %s", ace)
-		}
-	}
+// matchingAce scans aces for the entry that decides p for sid, preferring an exact match over one reached only
+// through cfg's wildcard Sid/Permission. The bool result reports whether the match was only a wildcard one, so the
+// caller can let a later, more specific ACE still win (least-specific-loses). Delegates to the shared MatchAce,
+// which domain.DefaultPermissionGrantingStrategy also uses.
+func matchingAce(aces []LegacyAce, p Permission, sid Sid, cfg *Config) (LegacyAce, bool) {
+	return MatchAce(aces, p, sid, cfg)
 }
 
 // DefaultPermissionGranter is the default permission granter
-func DefaultPermissionGranter(acl Acl, permissions []Permission, sids []Sid, admin bool, logger AuditLogger) (bool, error) {
-	if logger == nil {
-		logger = DefaultLogger
+func DefaultPermissionGranter(acl LegacyAcl, permissions []Permission, sids []Sid, admin bool, subject interface{}, auditor Auditor) (bool, error) {
+	if auditor == nil {
+		auditor = Console()
+	}
+	if admin {
+		// Admin-mode decisions must bypass auditing entirely, rather than have every call site below special-case
+		// suppressing it.
+		auditor = Noop()
+	}
+	// acl carries the AccessorID of the Authentication that triggered this check, if any, so it can be threaded
+	// into AuditEvents without widening the IsGranted/PermissionGranter signatures that every LegacyAcl implementation
+	// already agrees on.
+	var accessor string
+	if withAccessor, ok := acl.(interface{ CurrentAccessor() string }); ok {
+		accessor = withAccessor.CurrentAccessor()
+	}
+	// acl may also carry a Config overriding the wildcard Sid name/Permission consulted by matchingAce; fall back
+	// to the library defaults when it doesn't.
+	var cfg *Config
+	if withConfig, ok := acl.(interface{ Config() *Config }); ok {
+		cfg = withConfig.Config()
+	}
+	emit := func(granted bool, ace LegacyAce, reason string) {
+		auditor.Audit(AuditEvent{
+			Granted:     granted,
+			LegacyAce:   ace,
+			AccessorID:  accessor,
+			Oid:         acl.GetIdentity(),
+			Permission:  ace.GetPermission(),
+			Enforcement: ace.Enforcement(),
+			Reason:      reason,
+		})
 	}
 	aces := acl.GetEntries()
-	var firstRejection Ace
+	var firstRejection LegacyAce
 	for _, p := range permissions {
 		for _, sid := range sids {
-			// Attempt to find the exact match for this permission mask and SID
-			scanNextSid := false
-			for _, ace := range aces {
-				if ace.GetPermission().Match(p) && ace.GetSid().Equals(sid) {
-					// Found a matching ACE, so its authorization decision will prevail
-					if ace.IsGranting() {
-						// Success
-						if !admin {
-							logger(true, ace)
+			ace, wildcard := matchingAce(aces, p, sid, cfg)
+			if ace == nil {
+				continue
+			}
+			// Found a matching ACE, so its authorization decision will prevail, unless it is granting but scoped to
+			// subjects this one is not.
+			if ace.IsGranting() {
+				if scope := ace.Scope(); scope != nil {
+					if ok, err := scope(context.Background(), subject); err != nil || !ok {
+						if firstRejection == nil || !wildcard {
+							firstRejection = ace
 						}
-						return true, nil
+						break
 					}
-					// Failure for this permission, so stop search. We will see if they have a different permission
-					// (this permission is 100% rejected for this SID)
-					if firstRejection == nil {
-						// Store first rejection for auditing purposes
-						firstRejection = ace
-					}
-					scanNextSid = false // Helps break the loop
-					break
 				}
+				// Success
+				emit(true, ace, "granted")
+				return true, nil
 			}
-			if !scanNextSid {
-				break
+			// Failure for this permission, so stop search. We will see if they have a different permission (this
+			// permission is 100% rejected for this SID) - unless the only match was a wildcard entry, in which case
+			// a more specific ACE elsewhere in the ACL should still get a chance to grant.
+			if firstRejection == nil || !wildcard {
+				firstRejection = ace
 			}
+			break
 		}
 	}
 	if firstRejection != nil {
-		// We found an ACE to reject the request at this point, as no other ACEs where found that granted a different
-		// permission
-		if !admin {
-			logger(false, firstRejection)
+		switch level := firstRejection.Enforcement(); level {
+		case Advisory:
+			// Advisory ACEs never actually reject the request; only the audit trail records the denial.
+			emit(true, firstRejection, "advisory-deny")
+			return true, nil
+		case SoftMandatory:
+			if overridden(acl, accessor) {
+				emit(true, firstRejection, "override")
+				return true, nil
+			}
+			emit(false, firstRejection, "denied")
+		default: // HardMandatory
+			emit(false, firstRejection, "denied")
 		}
 	}
 
 	// No matches have been found so far
 	if parent := acl.GetParent(); parent != nil && acl.IsEntriesInheriting() {
-		return acl.IsGranted(permissions, sids, false)
+		return parent.IsGranted(permissions, sids, false, subject)
 	}
 	// We either have no parent or we're the uppermost parent
 	return false, ErrNotFound
 }
 
+// overridden reports whether acl carries an OverrideAuthorizer and token (see acl.WithOverrideAuthorizer and
+// acl.WithOverrideToken) that together authorize accessor to bypass a SoftMandatory denial.
+func overridden(acl LegacyAcl, accessor string) bool {
+	withAuthorizer, ok := acl.(interface{ OverrideAuthorizer() OverrideAuthorizer })
+	if !ok {
+		return false
+	}
+	authorizer := withAuthorizer.OverrideAuthorizer()
+	if authorizer == nil {
+		return false
+	}
+	withToken, ok := acl.(interface{ OverrideToken() string })
+	if !ok {
+		return false
+	}
+	return authorizer.AuthorizeOverride(accessor, withToken.OverrideToken())
+}
+
 // AuthoritySid is a Sid implementation holding a granted authority
 type AuthoritySid string
 
@@ -105,7 +160,7 @@ func (p PrincipalSid) GetPrincipal() string {
 // DefaultSidRetriever is the default function used to retrieve the list of Sid
 func DefaultSidRetriever(auth Authentication) []Sid {
 	roles := auth.GetAuthorities()
-	sids := make([]Sid, len(roles)+1)
+	sids := make([]Sid, 0, len(roles)+1)
 	sids = append(sids, PrincipalSid(auth.GetPrincipal()))
 	for _, role := range roles {
 		sids = append(sids, AuthoritySid(role))
@@ -165,10 +220,10 @@ func DefaultOidRetriever(object interface{}) (Oid, error) {
 	return nil, fmt.Errorf("Object %x does not provide a GetID method", object)
 }
 
-// ChangeType is the type of change that can be applied to an Acl.
+// ChangeType is the type of change that can be applied to an LegacyAcl.
 type ChangeType int
 
-// ChangeOwnership is a change in ownership of Acl
+// ChangeOwnership is a change in ownership of LegacyAcl
 // ChangeAuditing is a change of auditing behavior
 // ChangeGeneral is any other type of change
 const (
@@ -177,13 +232,13 @@ const (
 	ChangeGeneral
 )
 
-// Authorizer is used by Acl to determine whether a principal is permitted to call adminstrative methods
+// LegacyAuthorizer is used by LegacyAcl to determine whether a principal is permitted to call adminstrative methods
 // on the implementation itself
-type Authorizer interface {
-	SecurityCheck(Authentication, Acl, ChangeType) error
+type LegacyAuthorizer interface {
+	SecurityCheck(Authentication, LegacyAcl, ChangeType) error
 }
 
-// DefaultAuthorizer is the default implementation of Authorizer.
+// DefaultAuthorizer is the default implementation of LegacyAuthorizer.
 //
 // Permission will be granted if at least one of the following conditions is true for the current principal.
 // - Is the owner (as defined by ACL)
@@ -202,10 +257,15 @@ func NewDefaultAuthorizer(general, auditing, ownership string) *DefaultAuthorize
 }
 
 // SecurityCheck perform the security check for the given change type
-func (s *DefaultAuthorizer) SecurityCheck(auth Authentication, acl Acl, change ChangeType) error {
+func (s *DefaultAuthorizer) SecurityCheck(auth Authentication, acl LegacyAcl, change ChangeType) error {
 	if auth == nil {
 		return errors.New("Authenticated principal required to operate with ACLs")
 	}
+	// Record the accessor on acl, if it supports it, so DefaultPermissionGranter can attribute the IsGranted audit
+	// log entry below to the token that triggered it, without leaking its secret.
+	if impl, ok := acl.(interface{ setCurrentAccessor(string) }); ok {
+		impl.setCurrentAccessor(auth.GetAccessorID())
+	}
 	currentUser := PrincipalSid(auth.GetPrincipal())
 	if currentUser.Equals(acl.GetOwner()) && (change == ChangeGeneral || change == ChangeOwnership) {
 		return nil
@@ -231,86 +291,108 @@ func (s *DefaultAuthorizer) SecurityCheck(auth Authentication, acl Acl, change C
 	// Try to get permissions via ACEs within the ACL
 	sids := s.getSids(auth)
 	permissions := []Permission{AdministrationPermission}
-	if ok, err := acl.IsGranted(permissions, sids, false); err != nil && ok {
+	if ok, err := acl.IsGranted(permissions, sids, false, nil); err == nil && ok {
 		return nil
 	}
 
-	return errors.New("Principal does not have required ACL permissions to perform required operation.")
+	return fmt.Errorf("Principal %s does not have required ACL permissions to perform required operation.", auth.GetAccessorID())
 }
 
-// AccessControlEntry is the basic implementation of an Ace interface
-type accessControlEntry struct {
-	id         interface{}
-	acl        Acl
-	permission Permission
-	sid        Sid
-	granting   bool
-	succes     bool
-	failure    bool
+// legacyAccessControlEntry is the basic implementation of a LegacyAce
+type legacyAccessControlEntry struct {
+	id          interface{}
+	acl         LegacyAcl
+	permission  Permission
+	sid         Sid
+	granting    bool
+	succes      bool
+	failure     bool
+	enforcement EnforcementLevel
+	scope       ScopeFn
 }
 
-// NewAccessControlEntry will create a new Ace instance
-func newAccessControlEntry(id interface{}, acl Acl, sid Sid, permission Permission, granting, success, failure bool) (*accessControlEntry, error) {
+// newLegacyAccessControlEntry will create a new LegacyAce instance
+func newLegacyAccessControlEntry(id interface{}, acl LegacyAcl, sid Sid, permission Permission, granting, success, failure bool, enforcement EnforcementLevel, scope ScopeFn) (*legacyAccessControlEntry, error) {
 	if acl == nil {
-		return nil, errors.New("Acl object is required")
+		return nil, errors.New("LegacyAcl object is required")
 	}
 	if sid == nil {
 		return nil, errors.New("Sid object is required")
 	}
-	return &accessControlEntry{id, acl, permission, sid, granting, success, failure}, nil
+	return &legacyAccessControlEntry{id, acl, permission, sid, granting, success, failure, enforcement, scope}, nil
 }
 
-// GetAcl will retrieve the Acl
-func (ace *accessControlEntry) GetAcl() Acl {
+// GetAcl will retrieve the LegacyAcl
+func (ace *legacyAccessControlEntry) GetAcl() LegacyAcl {
 	return ace.acl
 }
 
 // GetID will retrieve the id
-func (ace *accessControlEntry) GetID() interface{} {
+func (ace *legacyAccessControlEntry) GetID() interface{} {
 	return ace.id
 }
 
 // GetPermission will retrieve the permission
-func (ace *accessControlEntry) GetPermission() Permission {
+func (ace *legacyAccessControlEntry) GetPermission() Permission {
 	return ace.permission
 }
 
 // GetSid will retrieve the Sid
-func (ace *accessControlEntry) GetSid() Sid {
+func (ace *legacyAccessControlEntry) GetSid() Sid {
 	return ace.sid
 }
 
 // IsAuditFailure check if this ACE should log failures
-func (ace *accessControlEntry) IsAuditFailure() bool {
+func (ace *legacyAccessControlEntry) IsAuditFailure() bool {
 	return ace.failure
 }
 
 // IsAuditSuccess check if this ACE should log successes
-func (ace *accessControlEntry) IsAuditSuccess() bool {
+func (ace *legacyAccessControlEntry) IsAuditSuccess() bool {
 	return ace.succes
 }
 
 // IsGranting check if this ACE permission are granted
-func (ace *accessControlEntry) IsGranting() bool {
+func (ace *legacyAccessControlEntry) IsGranting() bool {
 	return ace.granting
 }
 
+// Enforcement reports how a denial by this ACE affects IsGranted.
+func (ace *legacyAccessControlEntry) Enforcement() EnforcementLevel {
+	return ace.enforcement
+}
+
+// SetEnforcement will change the EnforcementLevel of this ACE
+func (ace *legacyAccessControlEntry) SetEnforcement(enforcement EnforcementLevel) {
+	ace.enforcement = enforcement
+}
+
+// Scope returns the ScopeFn, if any, gating this ACE's permission to a specific subject.
+func (ace *legacyAccessControlEntry) Scope() ScopeFn {
+	return ace.scope
+}
+
+// SetScope will change the ScopeFn of this ACE
+func (ace *legacyAccessControlEntry) SetScope(scope ScopeFn) {
+	ace.scope = scope
+}
+
 // SetAuditFailure will change the audit failure behavior
-func (ace *accessControlEntry) SetAuditFailure(failure bool) {
+func (ace *legacyAccessControlEntry) SetAuditFailure(failure bool) {
 	ace.failure = failure
 }
 
 // SetAuditSuccess will change the audit success behavior
-func (ace *accessControlEntry) SetAuditSuccess(success bool) {
+func (ace *legacyAccessControlEntry) SetAuditSuccess(success bool) {
 	ace.succes = success
 }
 
 // SetPermission will change the permission of this ACE
-func (ace *accessControlEntry) SetPermission(permission Permission) {
+func (ace *legacyAccessControlEntry) SetPermission(permission Permission) {
 	ace.permission = permission
 }
 
-func (ace *accessControlEntry) String() string {
+func (ace *legacyAccessControlEntry) String() string {
 	return fmt.Sprintf(
 		"AccessControlEntry[id: %s; granting: %t; sid: %s; permission: %s, auditSuccess: %t, auditFailure: %t]",
 		ace.id,
@@ -322,32 +404,90 @@ func (ace *accessControlEntry) String() string {
 	)
 }
 
-// acl is the implementation class of Acl interface
-type acl struct {
-	id         interface{}
-	oid        Oid
-	owner      Sid
-	parent     Acl
-	authorizer Authorizer
-	granter    PermissionGranter
-	aces       []Ace
-	inherits   bool
-	loadedSids []Sid
-	logger     AuditLogger
+// legacyAcl is the implementation class of LegacyAcl interface
+type legacyAcl struct {
+	id              interface{}
+	oid             Oid
+	owner           Sid
+	parent          LegacyAcl
+	authorizer      LegacyAuthorizer
+	granter         PermissionGranter
+	aces            []LegacyAce
+	inherits        bool
+	loadedSids      []Sid
+	auditor         Auditor
+	currentAccessor string
+	config          *Config
+	overrideToken   string
+	overrider       OverrideAuthorizer
+}
+
+// OverrideToken returns the override token set via WithOverrideToken, consulted by DefaultPermissionGranter when a
+// SoftMandatory LegacyAce would otherwise deny the request.
+func (a *legacyAcl) OverrideToken() string {
+	return a.overrideToken
 }
 
-// newAcl will create a new ACL instance with full parameters.
-func newAcl(oid Oid, id interface{}, auth Authorizer, granter PermissionGranter, log AuditLogger, parent Acl, loadedSids []Sid, inherits bool, owner Sid) (*acl, error) {
+// WithOverrideToken sets the token DefaultPermissionGranter will present to the OverrideAuthorizer to try to bypass
+// a SoftMandatory denial. Returns the receiver for chaining.
+func (a *legacyAcl) WithOverrideToken(token string) *legacyAcl {
+	a.overrideToken = token
+	return a
+}
+
+// OverrideAuthorizer returns the OverrideAuthorizer set via WithOverrideAuthorizer, or nil if none was configured.
+func (a *legacyAcl) OverrideAuthorizer() OverrideAuthorizer {
+	return a.overrider
+}
+
+// WithOverrideAuthorizer sets the OverrideAuthorizer DefaultPermissionGranter consults to decide whether
+// a.OverrideToken() entitles the current accessor to bypass a SoftMandatory denial. Returns the receiver for
+// chaining.
+func (a *legacyAcl) WithOverrideAuthorizer(overrider OverrideAuthorizer) *legacyAcl {
+	a.overrider = overrider
+	return a
+}
+
+// Config returns the Config consulted by matchingAce when this acl's IsGranted is evaluated, defaulting to
+// DefaultConfig when none was set via WithConfig.
+func (a *legacyAcl) Config() *Config {
+	if a.config == nil {
+		return DefaultConfig()
+	}
+	return a.config
+}
+
+// WithConfig overrides the wildcard Sid name/Permission this acl's DefaultPermissionGranter evaluation consults,
+// for deployments that want a sentinel other than "*"/AnyPermission. Returns the receiver for chaining.
+func (a *legacyAcl) WithConfig(config *Config) *legacyAcl {
+	a.config = config
+	return a
+}
+
+// setCurrentAccessor records the AccessorID of the Authentication most recently presented to this acl's
+// SecurityCheck, before that check decides, so DefaultPermissionGranter can attribute its audit log entries to it.
+func (a *legacyAcl) setCurrentAccessor(accessor string) {
+	a.currentAccessor = accessor
+}
+
+// CurrentAccessor returns the AccessorID recorded by the most recent SecurityCheck against this acl, whether or not
+// that check passed.
+func (a *legacyAcl) CurrentAccessor() string {
+	return a.currentAccessor
+}
+
+// newLegacyAcl will create a new ACL instance with full parameters.
+func newLegacyAcl(oid Oid, id interface{}, auth LegacyAuthorizer, granter PermissionGranter, auditor Auditor, parent LegacyAcl, loadedSids []Sid, inherits bool, owner Sid) (*legacyAcl, error) {
 	if auth == nil {
-		return nil, errors.New("Authorizer must not be null")
+		return nil, errors.New("LegacyAuthorizer must not be null")
 	}
 	if granter == nil {
 		return nil, errors.New("Granter must not be null")
 	}
-	if log == nil {
-		log = DefaultLogger
+	if auditor == nil {
+		auditor = Console()
 	}
-	return &acl{
+	return &legacyAcl{
 		id:         id,
 		oid:        oid,
 		owner:      owner,
@@ -356,38 +496,43 @@ func newAcl(oid Oid, id interface{}, auth Authorizer, granter PermissionGranter,
 		granter:    granter,
 		inherits:   inherits,
 		loadedSids: loadedSids,
-		logger:     log,
+		auditor:    auditor,
+		config:     DefaultConfig(),
 	}, nil
 }
 
-func (a *acl) verifyIndexExists(index int) error {
+func (a *legacyAcl) verifyIndexExists(index int) error {
 	if index < 0 {
 		return errors.New("index must be greater thant or equal to zero")
 	}
 	if index >= len(a.aces) {
-		return fmt.Errorf("index must refer to an index of Ace list. List size is %d, index was %d", len(a.aces), index)
+		return fmt.Errorf("index must refer to an index of LegacyAce list. List size is %d, index was %d", len(a.aces), index)
 	}
 	return nil
 }
 
-// InsertAce will create and insert a new Ace.
-func (a *acl) InsertAce(index int, permission Permission, sid Sid, granting bool) error {
-	// TODO retrieve Authentication object
-	var auth Authentication
-	a.authorizer.SecurityCheck(auth, a, ChangeGeneral)
+// InsertAce will create and insert a new LegacyAce, enforced at the given EnforcementLevel and, when scope is non-nil,
+// gated to subjects scope accepts.
+func (a *legacyAcl) InsertAce(auth Authentication, index int, permission Permission, sid Sid, granting bool, enforcement EnforcementLevel, scope ScopeFn) error {
+	if err := a.authorizer.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
+	}
 	if index < 0 || index > len(a.aces) {
 		return ErrNotFound
 	}
-	ace, err := newAccessControlEntry(nil, a, sid, permission, granting, false, false)
+	ace, err := newLegacyAccessControlEntry(nil, a, sid, permission, granting, false, false, enforcement, scope)
 	if err != nil {
 		return err
 	}
-	a.aces = append(a.aces[:index], append([]Ace{ace}, a.aces[index:]...)...)
+	a.aces = append(a.aces[:index], append([]LegacyAce{ace}, a.aces[index:]...)...)
 	return nil
 }
 
-// DeleteAce will delete the Ace at provided index
-func (a *acl) DeleteAce(index int) error {
+// DeleteAce will delete the LegacyAce at provided index
+func (a *legacyAcl) DeleteAce(auth Authentication, index int) error {
+	if err := a.authorizer.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
+	}
 	if err := a.verifyIndexExists(index); err != nil {
 		return err
 	}
@@ -396,36 +541,36 @@ func (a *acl) DeleteAce(index int) error {
 }
 
 // GetEntries will retrieve all the entries
-func (a *acl) GetEntries() []Ace {
-	result := make([]Ace, len(a.aces))
+func (a *legacyAcl) GetEntries() []LegacyAce {
+	result := make([]LegacyAce, len(a.aces))
 	copy(result, a.aces)
 	return result
 }
 
 // GetID will retrieve the unique object id
-func (a *acl) GetID() interface{} {
+func (a *legacyAcl) GetID() interface{} {
 	return a.id
 }
 
 // GetIdentity will retrieve the object identity
-func (a *acl) GetIdentity() Oid {
+func (a *legacyAcl) GetIdentity() Oid {
 	return a.oid
 }
 
 // IsEntriesInheriting will check if this acl object inherits
-func (a *acl) IsEntriesInheriting() bool {
+func (a *legacyAcl) IsEntriesInheriting() bool {
 	return a.inherits
 }
 
 // IsGranted delegates to Granter
-func (a *acl) IsGranted(permissions []Permission, sids []Sid, admin bool) (bool, error) {
+func (a *legacyAcl) IsGranted(permissions []Permission, sids []Sid, admin bool, subject interface{}) (bool, error) {
 	if !a.IsSidLoaded(sids) {
 		return false, ErrSidUnloaded
 	}
-	return a.granter(a, permissions, sids, admin, a.logger)
+	return a.granter(a, permissions, sids, admin, subject, a.auditor)
 }
 
-func (a *acl) IsSidLoaded(sids []Sid) bool {
+func (a *legacyAcl) IsSidLoaded(sids []Sid) bool {
 	// If loadedSids is nul, this indicates all SIDs were loaded. Also return true if the callre didn't specify a SID
 	if a.loadedSids == nil || sids == nil || len(sids) == 0 {
 		return true
@@ -447,8 +592,7 @@ func (a *acl) IsSidLoaded(sids []Sid) bool {
 	return true
 }
 
-func (a *acl) SetEntriesInheriting(entriesInheriting bool) error {
-	var auth Authentication
+func (a *legacyAcl) SetEntriesInheriting(auth Authentication, entriesInheriting bool) error {
 	if err := a.authorizer.SecurityCheck(auth, a, ChangeGeneral); err != nil {
 		return err
 	}
@@ -456,8 +600,7 @@ func (a *acl) SetEntriesInheriting(entriesInheriting bool) error {
 	return nil
 }
 
-func (a *acl) SetOwner(newOwner Sid) error {
-	var auth Authentication
+func (a *legacyAcl) SetOwner(auth Authentication, newOwner Sid) error {
 	if err := a.authorizer.SecurityCheck(auth, a, ChangeOwnership); err != nil {
 		return err
 	}
@@ -465,12 +608,11 @@ func (a *acl) SetOwner(newOwner Sid) error {
 	return nil
 }
 
-func (a *acl) GetOwner() Sid {
+func (a *legacyAcl) GetOwner() Sid {
 	return a.owner
 }
 
-func (a *acl) SetParent(newParent Acl) error {
-	var auth Authentication
+func (a *legacyAcl) SetParent(auth Authentication, newParent LegacyAcl) error {
 	if err := a.authorizer.SecurityCheck(auth, a, ChangeGeneral); err != nil {
 		return err
 	}
@@ -481,36 +623,36 @@ func (a *acl) SetParent(newParent Acl) error {
 	return nil
 }
 
-func (a *acl) GetParent() Acl {
+func (a *legacyAcl) GetParent() LegacyAcl {
 	return a.parent
 }
 
-func (a *acl) UpdateAce(index int, permission Permission) error {
-	var auth Authentication
+func (a *legacyAcl) UpdateAce(auth Authentication, index int, permission Permission, enforcement EnforcementLevel) error {
 	if err := a.authorizer.SecurityCheck(auth, a, ChangeGeneral); err != nil {
 		return err
 	}
 	if err := a.verifyIndexExists(index); err != nil {
 		return err
 	}
-	if ace, ok := a.aces[index].(*accessControlEntry); ok {
+	if ace, ok := a.aces[index].(*legacyAccessControlEntry); ok {
 		ace.SetPermission(permission)
+		ace.SetEnforcement(enforcement)
 		return nil
 	}
-	return errors.New("Ace is not of accessControlEntry type")
+	return errors.New("LegacyAce is not of legacyAccessControlEntry type")
 }
 
-func (a *acl) UpdateAuditing(index int, succes, failure bool) error {
-	var auth Authentication
+func (a *legacyAcl) UpdateAuditing(auth Authentication, index int, succes, failure bool) error {
 	if err := a.authorizer.SecurityCheck(auth, a, ChangeAuditing); err != nil {
 		return err
 	}
 	if err := a.verifyIndexExists(index); err != nil {
 		return err
 	}
-	if ace, ok := a.aces[index].(*accessControlEntry); ok {
+	if ace, ok := a.aces[index].(*legacyAccessControlEntry); ok {
 		ace.SetAuditSuccess(succes)
 		ace.SetAuditFailure(failure)
+		return nil
 	}
-	return errors.New("Ace is not of AccessControlEntryImpl type")
+	return errors.New("LegacyAce is not of AccessControlEntryImpl type")
 }
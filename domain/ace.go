@@ -16,20 +16,27 @@ type AccessControlEntry struct {
 	granting bool
 	succes   bool
 	failure  bool
+	scope    Scope
 }
 
 // NewAccessControlEntry will create a new Ace instance
 func NewAccessControlEntry(id interface{}, acl acl.Instance, sid acl.Sid, perm acl.Permission, granting, success, failure bool) (*AccessControlEntry, error) {
+	return NewScopedAccessControlEntry(id, acl, sid, perm, granting, success, failure, nil)
+}
+
+// NewScopedAccessControlEntry creates a new Ace instance whose grant is additionally gated by scope. A nil scope
+// behaves exactly like NewAccessControlEntry.
+func NewScopedAccessControlEntry(id interface{}, acl acl.Instance, sid acl.Sid, perm acl.Permission, granting, success, failure bool, scope Scope) (*AccessControlEntry, error) {
 	if acl == nil {
 		return nil, errors.New("Acl object is required")
 	}
 	if sid == nil {
 		return nil, errors.New("Sid object is required")
 	}
-	if perm == nil {
+	if perm == 0 {
 		return nil, errors.New("Permission object is required")
 	}
-	return &AccessControlEntry{acl, perm, id, sid, granting, success, failure}, nil
+	return &AccessControlEntry{acl, perm, id, sid, granting, success, failure, scope}, nil
 }
 
 // GetAcl will retrieve the Acl
@@ -82,6 +89,11 @@ func (ace *AccessControlEntry) SetPermission(perm acl.Permission) {
 	ace.perm = perm
 }
 
+// GetScope returns the Scope predicate gating this entry's grant, or nil if the entry is unconditional.
+func (ace *AccessControlEntry) GetScope() Scope {
+	return ace.scope
+}
+
 func (ace *AccessControlEntry) String() string {
 	return fmt.Sprintf(
 		"AccessControlEntry[id: %s; granting: %t; sid: %s; permission: %s, auditSuccess: %t, auditFailure: %t]",
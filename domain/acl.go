@@ -1,310 +1,271 @@
 package domain
 
-import "github.com/streamtune/acl"
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/streamtune/acl"
+)
 
 // Acl is the implementation class of acl.Instance interface
 type Acl struct {
 	id           interface{}
-	oid          acl.Identity
-	owner        acl.Sid
-	parent       acl.Instance
+	oid          acl.Oid
 	authStrategy AuthorizationStrategy
 	permStrategy acl.PermissionGrantingStrategy
-	aces         []acl.Ace
-	inherits     bool
-	loadedSids   []acl.Sid
+
+	mu         sync.RWMutex
+	owner      acl.Sid
+	parent     acl.Instance
+	aces       []acl.InstanceAce
+	inherits   bool
+	loadedSids []acl.Sid
 }
 
-func NewAcl(oid acl.Identity, id interface{}, auth AuthorizationStrategy, log AuditLogger) *Acl {
+// NewAcl is the full constructor, which should be used by persistence tools that do not provide field-level access
+// features. parent and loadedSids may be nil; a nil loadedSids means every Sid was loaded.
+func NewAcl(oid acl.Oid, id interface{}, auth AuthorizationStrategy, perm acl.PermissionGrantingStrategy, parent acl.Instance, loadedSids []acl.Sid, inherits bool, owner acl.Sid) *Acl {
 	return &Acl{
 		oid:          oid,
 		id:           id,
 		authStrategy: auth,
-		//permStrategy: NewDefaultPermissionGrantingStrategy(log),
+		permStrategy: perm,
+		owner:        owner,
+		parent:       parent,
+		loadedSids:   loadedSids,
+		inherits:     inherits,
 	}
 }
 
-/*
-	/**
-	 * Full constructor, which should be used by persistence tools that do not provide
-	 * field-level access features.
-	 *
-	 * @param objectIdentity the object identity this ACL relates to
-	 * @param id the primary key assigned to this ACL
-	 * @param aclAuthorizationStrategy authorization strategy
-	 * @param grantingStrategy the {@code PermissionGrantingStrategy} which will be used
-	 * by the {@code isGranted()} method
-	 * @param parentAcl the parent (may be may be {@code null})
-	 * @param loadedSids the loaded SIDs if only a subset were loaded (may be {@code null}
-	 * )
-	 * @param entriesInheriting if ACEs from the parent should inherit into this ACL
-	 * @param owner the owner (required)
-	 *
-	public AclImpl(ObjectIdentity objectIdentity, Serializable id,
-			AclAuthorizationStrategy aclAuthorizationStrategy,
-			PermissionGrantingStrategy grantingStrategy, Acl parentAcl,
-			List<Sid> loadedSids, boolean entriesInheriting, Sid owner) {
-		Assert.notNull(objectIdentity, "Object Identity required");
-		Assert.notNull(id, "Id required");
-		Assert.notNull(aclAuthorizationStrategy, "AclAuthorizationStrategy required");
-		Assert.notNull(owner, "Owner required");
-
-		this.objectIdentity = objectIdentity;
-		this.id = id;
-		this.aclAuthorizationStrategy = aclAuthorizationStrategy;
-		this.parentAcl = parentAcl; // may be null
-		this.loadedSids = loadedSids; // may be null
-		this.entriesInheriting = entriesInheriting;
-		this.owner = owner;
-		this.permissionGrantingStrategy = grantingStrategy;
-	}
+// MutableAcl is satisfied by an Acl that exposes the ACE-editing mutators, each of which runs
+// AuthorizationStrategy.SecurityCheck before mutating.
+type MutableAcl interface {
+	acl.Instance
 
-	/**
-	 * Private no-argument constructor for use by reflection-based persistence tools along
-	 * with field-level access.
-	 *
-	@SuppressWarnings("unused")
-	private AclImpl() {
-	}
+	// GetID obtains an identifier that represents this MutableAcl.
+	GetID() interface{}
 
-	// ~ Methods
-	// ========================================================================================================
+	// InsertAce inserts a new Ace at index.
+	InsertAce(auth acl.Authentication, index int, perm acl.Permission, sid acl.Sid, granting bool) error
 
-	public void deleteAce(int aceIndex) throws NotFoundException {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_GENERAL);
-		verifyAceIndexExists(aceIndex);
+	// UpdateAce updates the permission of the Ace at index.
+	UpdateAce(auth acl.Authentication, index int, perm acl.Permission) error
 
-		synchronized (aces) {
-			this.aces.remove(aceIndex);
-		}
-	}
+	// DeleteAce deletes the Ace at index.
+	DeleteAce(auth acl.Authentication, index int) error
 
-	private void verifyAceIndexExists(int aceIndex) {
-		if (aceIndex < 0) {
-			throw new NotFoundException("aceIndex must be greater than or equal to zero");
-		}
-		if (aceIndex >= this.aces.size()) {
-			throw new NotFoundException(
-					"aceIndex must refer to an index of the AccessControlEntry list. "
-							+ "List size is " + aces.size() + ", index was " + aceIndex);
-		}
-	}
+	// SetParent changes the parent Acl.
+	SetParent(auth acl.Authentication, parent acl.Instance) error
 
-	public void insertAce(int atIndexLocation, Permission permission, Sid sid,
-			boolean granting) throws NotFoundException {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_GENERAL);
-		Assert.notNull(permission, "Permission required");
-		Assert.notNull(sid, "Sid required");
-		if (atIndexLocation < 0) {
-			throw new NotFoundException(
-					"atIndexLocation must be greater than or equal to zero");
-		}
-		if (atIndexLocation > this.aces.size()) {
-			throw new NotFoundException(
-					"atIndexLocation must be less than or equal to the size of the AccessControlEntry collection");
-		}
+	// SetEntriesInheriting changes whether Aces from the parent Acl inherit into this one.
+	SetEntriesInheriting(auth acl.Authentication, inheriting bool) error
+}
 
-		AccessControlEntryImpl ace = new AccessControlEntryImpl(null, this, sid,
-				permission, granting, false, false);
+// OwnershipAcl is a MutableAcl that also allows transferring ownership.
+type OwnershipAcl interface {
+	MutableAcl
 
-		synchronized (aces) {
-			this.aces.add(atIndexLocation, ace);
-		}
-	}
+	// SetOwner changes the owner of this Acl.
+	SetOwner(auth acl.Authentication, owner acl.Sid) error
+}
 
-	public List<AccessControlEntry> getEntries() {
-		// Can safely return AccessControlEntry directly, as they're immutable outside the
-		// ACL package
-		return new ArrayList<AccessControlEntry>(aces);
-	}
+// AuditableAcl is an OwnershipAcl that also allows editing the per-Ace audit flags.
+type AuditableAcl interface {
+	OwnershipAcl
 
-	public Serializable getId() {
-		return this.id;
-	}
-
-	public ObjectIdentity getObjectIdentity() {
-		return objectIdentity;
-	}
+	// UpdateAuditing changes the audit success/failure flags of the Ace at index.
+	UpdateAuditing(auth acl.Authentication, index int, auditSuccess, auditFailure bool) error
+}
 
-	public boolean isEntriesInheriting() {
-		return entriesInheriting;
+// verifyAceIndexExists returns a *NotFoundError unless index refers to an existing entry of a.aces. Callers must
+// hold a.mu.
+func (a *Acl) verifyAceIndexExists(index int) error {
+	if index < 0 || index >= len(a.aces) {
+		return &NotFoundError{Index: index, Size: len(a.aces)}
 	}
+	return nil
+}
 
-	/**
-	 * Delegates to the {@link PermissionGrantingStrategy}.
-	 *
-	 * @throws UnloadedSidException if the passed SIDs are unknown to this ACL because the
-	 * ACL was only loaded for a subset of SIDs
-	 * @see DefaultPermissionGrantingStrategy
-	 *
-	public boolean isGranted(List<Permission> permission, List<Sid> sids,
-			boolean administrativeMode) throws NotFoundException, UnloadedSidException {
-		Assert.notEmpty(permission, "Permissions required");
-		Assert.notEmpty(sids, "SIDs required");
-
-		if (!this.isSidLoaded(sids)) {
-			throw new UnloadedSidException("ACL was not loaded for one or more SID");
-		}
+// InsertAce will create and insert a new, unscoped Ace at index, after checking that auth is authorized to perform
+// a ChangeGeneral on this Acl.
+func (a *Acl) InsertAce(auth acl.Authentication, index int, perm acl.Permission, sid acl.Sid, granting bool) error {
+	return a.InsertScopedAce(auth, index, perm, sid, granting, nil)
+}
 
-		return permissionGrantingStrategy.isGranted(this, permission, sids,
-				administrativeMode);
+// InsertScopedAce inserts a new Ace at index whose grant is additionally gated by scope - e.g. "role may read only
+// nodes in datacenter X". A nil scope behaves exactly like InsertAce.
+func (a *Acl) InsertScopedAce(auth acl.Authentication, index int, p acl.Permission, s acl.Sid, granting bool, scope Scope) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
 	}
-
-	public boolean isSidLoaded(List<Sid> sids) {
-		// If loadedSides is null, this indicates all SIDs were loaded
-		// Also return true if the caller didn't specify a SID to find
-		if ((this.loadedSids == null) || (sids == null) || (sids.size() == 0)) {
-			return true;
-		}
-
-		// This ACL applies to a SID subset only. Iterate to check it applies.
-		for (Sid sid : sids) {
-			boolean found = false;
-
-			for (Sid loadedSid : loadedSids) {
-				if (sid.equals(loadedSid)) {
-					// this SID is OK
-					found = true;
-
-					break; // out of loadedSids for loop
-				}
-			}
-
-			if (!found) {
-				return false;
-			}
-		}
-
-		return true;
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if index < 0 || index > len(a.aces) {
+		return &NotFoundError{Index: index, Size: len(a.aces)}
 	}
-
-	public void setEntriesInheriting(boolean entriesInheriting) {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_GENERAL);
-		this.entriesInheriting = entriesInheriting;
+	ace, err := NewScopedAccessControlEntry(nil, a, s, p, granting, false, false, scope)
+	if err != nil {
+		return err
 	}
+	a.aces = append(a.aces[:index], append([]acl.InstanceAce{ace}, a.aces[index:]...)...)
+	return nil
+}
 
-	public void setOwner(Sid newOwner) {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_OWNERSHIP);
-		Assert.notNull(newOwner, "Owner required");
-		this.owner = newOwner;
+// UpdateAce updates the permission of the Ace at index, after checking that auth is authorized to perform a
+// ChangeGeneral on this Acl.
+func (a *Acl) UpdateAce(auth acl.Authentication, index int, perm acl.Permission) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
 	}
-
-	public Sid getOwner() {
-		return this.owner;
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.verifyAceIndexExists(index); err != nil {
+		return err
 	}
+	a.aces[index].(*AccessControlEntry).SetPermission(perm)
+	return nil
+}
 
-	public void setParent(Acl newParent) {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_GENERAL);
-		Assert.isTrue(newParent == null || !newParent.equals(this),
-				"Cannot be the parent of yourself");
-		this.parentAcl = newParent;
+// UpdateAuditing updates the audit success/failure flags of the Ace at index, after checking that auth is
+// authorized to perform a ChangeAuditing on this Acl.
+func (a *Acl) UpdateAuditing(auth acl.Authentication, index int, auditSuccess, auditFailure bool) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeAuditing); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.verifyAceIndexExists(index); err != nil {
+		return err
 	}
+	ace := a.aces[index].(*AccessControlEntry)
+	ace.SetAuditSuccess(auditSuccess)
+	ace.SetAuditFailure(auditFailure)
+	return nil
+}
 
-	public Acl getParentAcl() {
-		return parentAcl;
+// DeleteAce deletes the Ace at index, after checking that auth is authorized to perform a ChangeGeneral on this
+// Acl.
+func (a *Acl) DeleteAce(auth acl.Authentication, index int) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.verifyAceIndexExists(index); err != nil {
+		return err
 	}
+	a.aces = append(a.aces[:index], a.aces[index+1:]...)
+	return nil
+}
 
-	public void updateAce(int aceIndex, Permission permission) throws NotFoundException {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_GENERAL);
-		verifyAceIndexExists(aceIndex);
+// GetEntries returns a defensive copy of all of the entries represented by the present Acl, safe for the caller to
+// retain and mutate even while the Acl is concurrently written to.
+func (a *Acl) GetEntries() []acl.InstanceAce {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	entries := make([]acl.InstanceAce, len(a.aces))
+	copy(entries, a.aces)
+	return entries
+}
 
-		synchronized (aces) {
-			AccessControlEntryImpl ace = (AccessControlEntryImpl) aces.get(aceIndex);
-			ace.setPermission(permission);
-		}
-	}
+// GetID will retrieve the id
+func (a *Acl) GetID() interface{} {
+	return a.id
+}
+
+// GetIdentity will retrieve the object identity
+func (a *Acl) GetIdentity() acl.Oid {
+	return a.oid
+}
 
-	public void updateAuditing(int aceIndex, boolean auditSuccess, boolean auditFailure) {
-		aclAuthorizationStrategy.securityCheck(this,
-				AclAuthorizationStrategy.CHANGE_AUDITING);
-		verifyAceIndexExists(aceIndex);
+// GetOwner determines the owner of the Acl.
+func (a *Acl) GetOwner() acl.Sid {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.owner
+}
 
-		synchronized (aces) {
-			AccessControlEntryImpl ace = (AccessControlEntryImpl) aces.get(aceIndex);
-			ace.setAuditSuccess(auditSuccess);
-			ace.setAuditFailure(auditFailure);
-		}
+// SetOwner changes the current owner to a different one, after checking that auth is authorized to perform a
+// ChangeOwnership on this Acl.
+func (a *Acl) SetOwner(auth acl.Authentication, owner acl.Sid) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeOwnership); err != nil {
+		return err
 	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.owner = owner
+	return nil
+}
 
-	public boolean equals(Object obj) {
-		if (obj instanceof AclImpl) {
-			AclImpl rhs = (AclImpl) obj;
-			if (this.aces.equals(rhs.aces)) {
-				if ((this.parentAcl == null && rhs.parentAcl == null)
-						|| (this.parentAcl != null && this.parentAcl
-								.equals(rhs.parentAcl))) {
-					if ((this.objectIdentity == null && rhs.objectIdentity == null)
-							|| (this.objectIdentity != null && this.objectIdentity
-									.equals(rhs.objectIdentity))) {
-						if ((this.id == null && rhs.id == null)
-								|| (this.id != null && this.id.equals(rhs.id))) {
-							if ((this.owner == null && rhs.owner == null)
-									|| (this.owner != null && this.owner
-											.equals(rhs.owner))) {
-								if (this.entriesInheriting == rhs.entriesInheriting) {
-									if ((this.loadedSids == null && rhs.loadedSids == null)) {
-										return true;
-									}
-									if (this.loadedSids != null
-											&& (this.loadedSids.size() == rhs.loadedSids
-													.size())) {
-										for (int i = 0; i < this.loadedSids.size(); i++) {
-											if (!this.loadedSids.get(i).equals(
-													rhs.loadedSids.get(i))) {
-												return false;
-											}
-										}
-										return true;
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-		return false;
+// GetParent will retrieve the parent Acl, or nil if there is none.
+func (a *Acl) GetParent() acl.Instance {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.parent
+}
+
+// SetParent changes the parent Acl, after checking that auth is authorized to perform a ChangeGeneral on this Acl.
+func (a *Acl) SetParent(auth acl.Authentication, parent acl.Instance) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
 	}
+	if instance, ok := parent.(*Acl); ok && instance == a {
+		return errors.New("domain: acl cannot be the parent of itself")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.parent = parent
+	return nil
+}
 
-	public String toString() {
-		StringBuilder sb = new StringBuilder();
-		sb.append("AclImpl[");
-		sb.append("id: ").append(this.id).append("; ");
-		sb.append("objectIdentity: ").append(this.objectIdentity).append("; ");
-		sb.append("owner: ").append(this.owner).append("; ");
+// IsEntriesInheriting indicates whether the Ace entries from GetParent should flow down into this Acl.
+func (a *Acl) IsEntriesInheriting() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.inherits
+}
 
-		int count = 0;
+// SetEntriesInheriting changes the value returned by IsEntriesInheriting, after checking that auth is authorized
+// to perform a ChangeGeneral on this Acl.
+func (a *Acl) SetEntriesInheriting(auth acl.Authentication, inheriting bool) error {
+	if err := a.authStrategy.SecurityCheck(auth, a, ChangeGeneral); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inherits = inheriting
+	return nil
+}
 
-		for (AccessControlEntry ace : aces) {
-			count++;
+// IsGranted delegates to the PermissionGrantingStrategy, after checking that every requested sid was loaded for
+// this Acl.
+func (a *Acl) IsGranted(perms []acl.Permission, sids []acl.Sid, adminMode bool) (bool, error) {
+	if err := a.IsSidLoaded(sids); err != nil {
+		return false, err
+	}
+	return a.permStrategy.IsGranted(context.Background(), a, perms, sids, adminMode, nil)
+}
 
-			if (count == 1) {
-				sb.append("\n");
+// IsSidLoaded returns an *UnloadedSidError unless every one of sids falls within the subset this Acl was loaded
+// for. A nil loadedSids (the default) or an empty sids means every Sid was loaded, so nil is returned.
+func (a *Acl) IsSidLoaded(sids []acl.Sid) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.loadedSids == nil || len(sids) == 0 {
+		return nil
+	}
+	for _, sid := range sids {
+		found := false
+		for _, loaded := range a.loadedSids {
+			if sid.Equals(loaded) {
+				found = true
+				break
 			}
-
-			sb.append(ace).append("\n");
 		}
-
-		if (count == 0) {
-			sb.append("no ACEs; ");
+		if !found {
+			return &UnloadedSidError{Sid: sid}
 		}
-
-		sb.append("inheriting: ").append(this.entriesInheriting).append("; ");
-		sb.append("parent: ").append(
-				(this.parentAcl == null) ? "Null" : this.parentAcl.getObjectIdentity()
-						.toString());
-		sb.append("; ");
-		sb.append("aclAuthorizationStrategy: ").append(this.aclAuthorizationStrategy)
-				.append("; ");
-		sb.append("permissionGrantingStrategy: ").append(this.permissionGrantingStrategy);
-		sb.append("]");
-
-		return sb.toString();
 	}
-*/
+	return nil
+}
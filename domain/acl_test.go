@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamtune/acl"
+)
+
+// allowAuth always permits every SecurityCheck; denyAuth, below it, rejects every SecurityCheck with its configured
+// error, for the negative path.
+type allowAuth struct{}
+
+func (allowAuth) SecurityCheck(acl.Authentication, acl.Instance, ChangeType) error { return nil }
+
+type denyAuth struct{ err error }
+
+func (d denyAuth) SecurityCheck(acl.Authentication, acl.Instance, ChangeType) error { return d.err }
+
+// stubGrantingStrategy records the last call it received and returns a configurable result, so IsGranted's
+// delegation can be asserted without depending on DefaultPermissionGrantingStrategy's own behavior.
+type stubGrantingStrategy struct {
+	granted  bool
+	err      error
+	instance acl.Instance
+	perms    []acl.Permission
+	sids     []acl.Sid
+	admin    bool
+}
+
+func (s *stubGrantingStrategy) IsGranted(ctx context.Context, instance acl.Instance, perms []acl.Permission, sids []acl.Sid, admin bool, resource interface{}) (bool, error) {
+	s.instance, s.perms, s.sids, s.admin = instance, perms, sids, admin
+	return s.granted, s.err
+}
+
+func newTestOid() acl.Oid {
+	return acl.NewObjectIdentity("Doc", 1)
+}
+
+func newTestAcl(auth AuthorizationStrategy, perm acl.PermissionGrantingStrategy) *Acl {
+	return NewAcl(newTestOid(), 1, auth, perm, nil, nil, false, acl.PrincipalSid("owner"))
+}
+
+func TestAclInsertUpdateDeleteAce(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+
+	if err := a.InsertAce(nil, 0, acl.ReadPermission, acl.PrincipalSid("alice"), true); err != nil {
+		t.Fatalf("InsertAce: %v", err)
+	}
+	entries := a.GetEntries()
+	if len(entries) != 1 || entries[0].GetPermission() != acl.ReadPermission {
+		t.Fatalf("GetEntries after insert = %v, want a single read-granting entry", entries)
+	}
+
+	if err := a.UpdateAce(nil, 0, acl.WritePermission); err != nil {
+		t.Fatalf("UpdateAce: %v", err)
+	}
+	if got := a.GetEntries()[0].GetPermission(); got != acl.WritePermission {
+		t.Fatalf("GetEntries()[0].GetPermission() = %v, want WritePermission", got)
+	}
+
+	if err := a.DeleteAce(nil, 0); err != nil {
+		t.Fatalf("DeleteAce: %v", err)
+	}
+	if len(a.GetEntries()) != 0 {
+		t.Fatalf("expected GetEntries to be empty after DeleteAce, got %v", a.GetEntries())
+	}
+}
+
+func TestAclInsertUpdateDeleteAceRejectedByAuthorizationStrategy(t *testing.T) {
+	denied := &NotFoundError{} // any distinct error works; SecurityCheck just needs to return non-nil
+	a := newTestAcl(denyAuth{err: denied}, &stubGrantingStrategy{})
+
+	if err := a.InsertAce(nil, 0, acl.ReadPermission, acl.PrincipalSid("alice"), true); err != denied {
+		t.Fatalf("InsertAce error = %v, want the AuthorizationStrategy's denial", err)
+	}
+	if len(a.GetEntries()) != 0 {
+		t.Fatal("expected a rejected InsertAce not to mutate the Acl")
+	}
+	if err := a.UpdateAce(nil, 0, acl.WritePermission); err != denied {
+		t.Fatalf("UpdateAce error = %v, want the AuthorizationStrategy's denial", err)
+	}
+	if err := a.DeleteAce(nil, 0); err != denied {
+		t.Fatalf("DeleteAce error = %v, want the AuthorizationStrategy's denial", err)
+	}
+}
+
+func TestAclAceIndexOutOfRange(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+
+	if err := a.UpdateAce(nil, 0, acl.ReadPermission); err == nil {
+		t.Fatal("expected UpdateAce on an empty Acl to report a NotFoundError")
+	} else if _, ok := err.(*NotFoundError); !ok {
+		t.Fatalf("UpdateAce error = %T, want *NotFoundError", err)
+	}
+}
+
+func TestAclGetEntriesReturnsDefensiveCopy(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+	if err := a.InsertAce(nil, 0, acl.ReadPermission, acl.PrincipalSid("alice"), true); err != nil {
+		t.Fatalf("InsertAce: %v", err)
+	}
+
+	entries := a.GetEntries()
+	entries[0] = nil
+
+	if a.GetEntries()[0] == nil {
+		t.Fatal("mutating the slice returned by GetEntries must not affect the Acl's own entries")
+	}
+}
+
+func TestAclSetOwnerAndParent(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+
+	if err := a.SetOwner(nil, acl.PrincipalSid("bob")); err != nil {
+		t.Fatalf("SetOwner: %v", err)
+	}
+	if got := a.GetOwner(); !got.Equals(acl.PrincipalSid("bob")) {
+		t.Fatalf("GetOwner() = %v, want PrincipalSid(bob)", got)
+	}
+
+	parent := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+	if err := a.SetParent(nil, parent); err != nil {
+		t.Fatalf("SetParent: %v", err)
+	}
+	if a.GetParent() != acl.Instance(parent) {
+		t.Fatal("expected GetParent to return the Acl passed to SetParent")
+	}
+}
+
+func TestAclSetParentRejectsSelf(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+	if err := a.SetParent(nil, a); err == nil {
+		t.Fatal("expected SetParent to reject an Acl becoming its own parent")
+	}
+}
+
+func TestAclSetEntriesInheriting(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+	if a.IsEntriesInheriting() {
+		t.Fatal("expected a freshly constructed Acl to not inherit by default in this test")
+	}
+	if err := a.SetEntriesInheriting(nil, true); err != nil {
+		t.Fatalf("SetEntriesInheriting: %v", err)
+	}
+	if !a.IsEntriesInheriting() {
+		t.Fatal("expected IsEntriesInheriting to reflect the value passed to SetEntriesInheriting")
+	}
+}
+
+func TestAclUpdateAuditing(t *testing.T) {
+	a := newTestAcl(allowAuth{}, &stubGrantingStrategy{})
+	if err := a.InsertAce(nil, 0, acl.ReadPermission, acl.PrincipalSid("alice"), true); err != nil {
+		t.Fatalf("InsertAce: %v", err)
+	}
+	if err := a.UpdateAuditing(nil, 0, true, true); err != nil {
+		t.Fatalf("UpdateAuditing: %v", err)
+	}
+	entry := a.GetEntries()[0].(*AccessControlEntry)
+	if !entry.IsAuditSuccess() || !entry.IsAuditFailure() {
+		t.Fatal("expected UpdateAuditing to set both audit flags")
+	}
+}
+
+func TestAclIsGrantedDelegatesToPermissionGrantingStrategy(t *testing.T) {
+	strategy := &stubGrantingStrategy{granted: true}
+	a := newTestAcl(allowAuth{}, strategy)
+	alice := acl.PrincipalSid("alice")
+
+	granted, err := a.IsGranted([]acl.Permission{acl.ReadPermission}, []acl.Sid{alice}, false)
+	if err != nil {
+		t.Fatalf("IsGranted: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected IsGranted to return the PermissionGrantingStrategy's decision")
+	}
+	if strategy.instance != acl.Instance(a) {
+		t.Fatal("expected IsGranted to pass itself as the Instance to the PermissionGrantingStrategy")
+	}
+}
+
+func TestAclIsGrantedRejectsUnloadedSid(t *testing.T) {
+	alice := acl.PrincipalSid("alice")
+	a := NewAcl(newTestOid(), 1, allowAuth{}, &stubGrantingStrategy{granted: true}, nil, []acl.Sid{alice}, false, alice)
+
+	_, err := a.IsGranted([]acl.Permission{acl.ReadPermission}, []acl.Sid{acl.PrincipalSid("bob")}, false)
+	if err == nil {
+		t.Fatal("expected IsGranted to reject a Sid outside the loaded subset")
+	}
+	if _, ok := err.(*UnloadedSidError); !ok {
+		t.Fatalf("IsGranted error = %T, want *UnloadedSidError", err)
+	}
+}
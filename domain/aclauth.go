@@ -1,9 +1,10 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/streamtune/acl"
+	"github.com/streamtune/acl/policy"
 )
 
 // ChangeType is the type of change that can be applied to an Acl.
@@ -36,23 +37,90 @@ type DefaultAuthorizationStrategy struct {
 	auditingChange  string
 	ownershipChange string
 	sidrs           acl.SidRetrievalStrategy
+	policies        *policy.PolicyManager
 }
 
 // NewDefaultAuthorizationStrategy will create a new default AuthorizationStrategy
 func NewDefaultAuthorizationStrategy(general, auditing, ownership string) *DefaultAuthorizationStrategy {
-	return &DefaultAuthorizationStrategy{general, auditing, ownership, NewDefaultSidRetrievalStrategy()}
+	return &DefaultAuthorizationStrategy{general, auditing, ownership, NewDefaultSidRetrievalStrategy(), nil}
 }
 
-// SecurityCheck perform the security check for the given change type
+// GrantedAuthority names an authority a principal may hold, e.g. a role granted administrative rights over ACLs.
+// It mirrors Spring Security's GrantedAuthority, which is likewise just a string wrapper.
+type GrantedAuthority string
+
+// NewAuthorizationStrategy ports Spring's AclAuthorizationStrategyImpl(GrantedAuthority, GrantedAuthority,
+// GrantedAuthority) constructor: it checks a distinct authority for each of the three administrative change kinds,
+// falling back to the ACL owner / administrative SID check when none match.
+func NewAuthorizationStrategy(owner, audit, general GrantedAuthority) *DefaultAuthorizationStrategy {
+	return NewDefaultAuthorizationStrategy(string(general), string(audit), string(owner))
+}
+
+// NewAuthorizationStrategyFromSingle is a convenience constructor that reuses auth for all three change kinds,
+// mirroring Spring's single-GrantedAuthority AclAuthorizationStrategyImpl constructor.
+func NewAuthorizationStrategyFromSingle(auth GrantedAuthority) *DefaultAuthorizationStrategy {
+	return NewAuthorizationStrategy(auth, auth, auth)
+}
+
+// WithPolicyManager attaches manager to s. When set, SecurityCheck consults manager before falling back to its own
+// general/auditing/ownership authority strings, so administrative-change decisions can be expressed as declarative
+// policy.Grants instead of only the hard-coded switch over ChangeType. Returns s for chaining.
+func (s *DefaultAuthorizationStrategy) WithPolicyManager(manager *policy.PolicyManager) *DefaultAuthorizationStrategy {
+	s.policies = manager
+	return s
+}
+
+// WithSidRetrievalStrategy overrides the SidRetrievalStrategy s falls back to when resolving the ACEs of instance
+// for the administrative-permission check, in place of the NewDefaultSidRetrievalStrategy used by default. Returns
+// s for chaining.
+func (s *DefaultAuthorizationStrategy) WithSidRetrievalStrategy(sidrs acl.SidRetrievalStrategy) *DefaultAuthorizationStrategy {
+	s.sidrs = sidrs
+	return s
+}
+
+// changeAction maps a ChangeType to the action name consulted against policy.Grant.Actions.
+func changeAction(change ChangeType) string {
+	switch change {
+	case ChangeAuditing:
+		return "change:auditing"
+	case ChangeOwnership:
+		return "change:ownership"
+	default:
+		return "change:general"
+	}
+}
+
+// SecurityCheck perform the security check for the given change type. Denials are returned as a
+// *acl.PermissionDeniedError carrying the accessor, the target resource and the change/permission that was
+// required, so callers can discriminate "no principal in context" from "unsupported change type" from "denied by
+// ACL" without matching on an error string.
+//
+// domain predates the sid/permission package split, so its Sid/Permission types cannot satisfy the sid.Sid/
+// permission.Permission interfaces required by the acl/errors package; it uses the looser, interface{}-based
+// acl.PermissionDeniedError instead (see DefaultPermissionGrantingStrategy.IsGranted).
 func (s *DefaultAuthorizationStrategy) SecurityCheck(auth acl.Authentication, instance acl.Instance, change ChangeType) error {
 	if auth == nil {
-		return errors.New("Authenticated principal required to operate with ACLs")
+		return &acl.PermissionDeniedError{Cause: "authenticated principal required to operate with ACLs"}
 	}
-	currentUser := NewPrincipalSid(auth.GetPrincipal())
+	currentUser := PrincipalSid(auth.GetPrincipal())
 	if currentUser.Equals(instance.GetOwner()) && (change == ChangeGeneral || change == ChangeOwnership) {
 		return nil
 	}
-	// Not authorized by ACL ownership; try via administrtive permissions
+	// If a PolicyManager is attached, let it decide before falling back to the general/auditing/ownership
+	// authority strings below, so administrative-change decisions can be expressed as policy.Grants.
+	if s.policies != nil {
+		identity := instance.GetIdentity()
+		subject := policy.Subject{ID: auth.GetPrincipal(), Roles: auth.GetAuthorities()}
+		resource := policy.Resource{Type: identity.GetType(), ID: fmt.Sprintf("%v", identity.GetIdentifier())}
+		granted, err := s.policies.IsGranted(subject, resource, changeAction(change), nil)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return nil
+		}
+	}
+	// Not authorized by ACL ownership nor policy; try via administrtive permissions
 	var requiredAuthority string
 	switch change {
 	case ChangeAuditing:
@@ -62,7 +130,7 @@ func (s *DefaultAuthorizationStrategy) SecurityCheck(auth acl.Authentication, in
 	case ChangeOwnership:
 		requiredAuthority = s.ownershipChange
 	default:
-		return errors.New("Unsupported change type")
+		return &acl.PermissionDeniedError{Accessor: currentUser, Permission: change, Oid: instance.GetIdentity(), Cause: "unsupported change type"}
 	}
 	// Iterate the principal's authorities to determine right
 	for _, v := range auth.GetAuthorities() {
@@ -72,10 +140,10 @@ func (s *DefaultAuthorizationStrategy) SecurityCheck(auth acl.Authentication, in
 	}
 	// Try to get permissions via ACEs within the ACL
 	sids := s.sidrs.GetSids(auth)
-	perms := []acl.Permission{AdministrationPermission}
-	if ok, err := instance.IsGranted(perms, sids, false); err != nil && ok {
+	perms := []acl.Permission{acl.AdministrationPermission}
+	if ok, err := instance.IsGranted(perms, sids, false); err == nil && ok {
 		return nil
 	}
 
-	return errors.New("Principal does not have required ACL permissions to perform required operation.")
+	return &acl.PermissionDeniedError{Accessor: currentUser, Permission: change, Oid: instance.GetIdentity()}
 }
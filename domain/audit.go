@@ -5,7 +5,7 @@ import "fmt"
 
 // AuditLogger is used in order to audit logging data.
 type AuditLogger interface {
-	LogIfNeeded(granted bool, ace acl.Ace)
+	LogIfNeeded(granted bool, ace acl.InstanceAce)
 }
 
 // ConsoleAuditLogger is an AuditLogger used to log audit information on console
@@ -17,9 +17,17 @@ func NewConsoleAuditLogger() *ConsoleAuditLogger {
 	return &ConsoleAuditLogger{}
 }
 
+// auditableAce is satisfied by an InstanceAce that also carries audit-on-grant/audit-on-deny flags, such as
+// AccessControlEntry. It is domain-local because InstanceAce itself (the root acl.InstanceAce) doesn't mandate
+// auditing support.
+type auditableAce interface {
+	IsAuditSuccess() bool
+	IsAuditFailure() bool
+}
+
 // LogIfNeeded is the method invoked when someone wants to log a grant or a deny action.
-func (c *ConsoleAuditLogger) LogIfNeeded(granted bool, ace acl.Ace) {
-	if auditable, ok := ace.(acl.AuditableAce); ok {
+func (c *ConsoleAuditLogger) LogIfNeeded(granted bool, ace acl.InstanceAce) {
+	if auditable, ok := ace.(auditableAce); ok {
 		if granted && auditable.IsAuditSuccess() {
 			fmt.Printf("Granted due to ACE %s", ace)
 		} else if !granted && auditable.IsAuditFailure() {
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/streamtune/acl"
+)
+
+// NotFoundError is returned by an Acl mutator when the Ace index it was given does not refer to an entry currently
+// held by the Acl, e.g. because it is negative or the Acl has since shrunk.
+type NotFoundError struct {
+	Index int
+	Size  int
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("domain: ace index %d must refer to an entry of the Acl, which has %d entries", e.Index, e.Size)
+}
+
+// UnloadedSidError is returned by Acl.IsSidLoaded (and anything delegating to it, such as IsGranted) when the Acl
+// was only loaded for a subset of Sids and Sid falls outside that subset.
+type UnloadedSidError struct {
+	Sid acl.Sid
+}
+
+// Error implements the error interface.
+func (e *UnloadedSidError) Error() string {
+	return fmt.Sprintf("domain: acl was not loaded for sid %v", e.Sid)
+}
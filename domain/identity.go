@@ -3,7 +3,7 @@ package domain
 import "github.com/streamtune/acl"
 import "fmt"
 
-// Identity is the concrete type for acl.Identity interface
+// Identity is the concrete type for acl.Oid interface
 type Identity struct {
 	kind string
 	id   interface{}
@@ -25,7 +25,7 @@ func (i *Identity) GetType() string {
 }
 
 // Equals will check if this identity is equal to other one
-func (i *Identity) Equals(other acl.Identity) bool {
+func (i *Identity) Equals(other acl.Oid) bool {
 	if o, ok := other.(*Identity); ok {
 		return i.id == o.id && i.kind == o.kind
 	}
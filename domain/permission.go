@@ -1,48 +1,72 @@
 package domain
 
-import "github.com/streamtune/acl"
+import (
+	"context"
+
+	"github.com/streamtune/acl"
+)
 
 // DefaultPermissionGrantingStrategy is the default permission granting strategy implmentation.
 type DefaultPermissionGrantingStrategy struct {
 	logger AuditLogger
+	config *acl.Config
 }
 
 // NewDefaultPermissionGrantingStrategy is the factory method used to create a new default PermissionGrantingStrategy
 func NewDefaultPermissionGrantingStrategy(logger AuditLogger) *DefaultPermissionGrantingStrategy {
-	return &DefaultPermissionGrantingStrategy{logger}
+	return NewDefaultPermissionGrantingStrategyWithConfig(logger, acl.DefaultConfig())
+}
+
+// NewDefaultPermissionGrantingStrategyWithConfig is like NewDefaultPermissionGrantingStrategy but lets the caller
+// override the wildcard sentinel permission consulted by IsGranted (see acl.Config.WildcardPermission), for
+// deployments that want wildcard grants distinguishable from "happens to have every flag set".
+func NewDefaultPermissionGrantingStrategyWithConfig(logger AuditLogger, config *acl.Config) *DefaultPermissionGrantingStrategy {
+	return &DefaultPermissionGrantingStrategy{logger, config}
 }
 
-// IsGranted will check the permission
-func (s *DefaultPermissionGrantingStrategy) IsGranted(instance acl.Instance, perms []acl.Permission, sids []acl.Sid, admin bool) (bool, error) {
+// IsGranted will check the permission. An Ace bound to acl.Wildcard matches every requested Sid, and an Ace bound
+// to acl.AnyPermission matches every requested Permission; acl.MatchAce makes both checks and prefers an exact Sid
+// match over a wildcard one.
+//
+// When a matching Ace also carries a Scope, resource is evaluated against it and the Ace, granting or denying, only
+// applies if the predicate returns true; otherwise the next Sid is tried - this lets callers express
+// attribute/relationship-based rules (e.g. "only if doc.OwnerID == user.ID") on top of the usual Permission/Sid
+// matching.
+func (s *DefaultPermissionGrantingStrategy) IsGranted(ctx context.Context, instance acl.Instance, perms []acl.Permission, sids []acl.Sid, admin bool, resource interface{}) (bool, error) {
 	aces := instance.GetEntries()
-	var firstRejection acl.Ace
+	var firstRejection acl.InstanceAce
 	for _, p := range perms {
 		for _, sid := range sids {
-			// Attempt to find the exact match for this permission mask and SID
-			scanNextSid := false
-			for _, ace := range aces {
-				if ace.GetPermission().HasFlag(uint32(p)) && ace.GetSid().Equals(sid) {
-					// Found a matching ACE, so its authorization decision will prevail
-					if ace.IsGranting() {
-						// Success
-						if !admin {
-							s.logger.LogIfNeeded(true, ace)
-						}
-						return true, nil
-					}
-					// Failure for this permission, so stop search. We will see if they have a different permission
-					// (this permission is 100% rejected for this SID)
-					if firstRejection == nil {
-						// Store first rejection for auditing purposes
-						firstRejection = ace
-					}
-					scanNextSid = false // Helps break the loop
-					break
+			// Find the entry that decides p for sid, preferring an exact Sid match over one reached only through
+			// the wildcard Sid/Permission (see acl.MatchAce, shared with the legacy DefaultPermissionGranter).
+			ace, wildcard := acl.MatchAce(aces, p, sid, s.config)
+			if ace == nil {
+				continue
+			}
+			if scoped, ok := ace.(interface{ GetScope() Scope }); ok && scoped.GetScope() != nil {
+				matches, err := scoped.GetScope().Evaluate(ctx, resource)
+				if err != nil {
+					return false, err
 				}
+				if !matches {
+					// The scope rejects this entry; move on to the next Sid as if it hadn't matched at all.
+					continue
+				}
+			}
+			// Found a matching ACE, so its authorization decision will prevail
+			if ace.IsGranting() {
+				// Success
+				if !admin {
+					s.logger.LogIfNeeded(true, ace)
+				}
+				return true, nil
 			}
-			if !scanNextSid {
-				break
+			// Failure for this permission, unless the only match was a wildcard entry, in which case a more
+			// specific ACE elsewhere in the ACL should still get a chance to grant.
+			if firstRejection == nil || !wildcard {
+				firstRejection = ace
 			}
+			break
 		}
 	}
 	if firstRejection != nil {
@@ -58,5 +82,9 @@ func (s *DefaultPermissionGrantingStrategy) IsGranted(instance acl.Instance, per
 		return parent.IsGranted(perms, sids, false)
 	}
 	// We either have no parent or we're the uppermost parent
-	return false, acl.ErrNotFound
+	var accessor acl.Sid
+	if len(sids) > 0 {
+		accessor = sids[0]
+	}
+	return false, &acl.PermissionDeniedError{Accessor: accessor, Permission: perms, Oid: instance.GetIdentity()}
 }
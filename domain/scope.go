@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// Scope is a runtime predicate attached to an AccessControlEntry. It lets an entry grant or deny a permission
+// conditionally on the resource being authorized - e.g. "user may write this document only if it is in draft
+// state" - instead of purely on Permission/Sid matching.
+type Scope interface {
+	// Evaluate decides whether the entry's grant applies to resource in the current context.
+	Evaluate(ctx context.Context, resource interface{}) (bool, error)
+}
+
+// ScopeFunc adapts a plain function to the Scope interface.
+type ScopeFunc func(ctx context.Context, resource interface{}) (bool, error)
+
+// Evaluate calls f.
+func (f ScopeFunc) Evaluate(ctx context.Context, resource interface{}) (bool, error) {
+	return f(ctx, resource)
+}
@@ -1,6 +1,12 @@
 package domain
 
-import "github.com/streamtune/acl"
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/streamtune/acl"
+)
 
 // AuthoritySid is a Sid implementation holding a granted authority
 type AuthoritySid string
@@ -8,14 +14,14 @@ type AuthoritySid string
 // Equals will check if the receiver is equal to provided Sid implementation
 func (s AuthoritySid) Equals(other acl.Sid) bool {
 	if o, ok := other.(AuthoritySid); ok {
-		return s.authority == o.authority
+		return s == o
 	}
 	return false
 }
 
 // GetAuthority retrieve the authority for the receiver authority Sid.
 func (s AuthoritySid) GetAuthority() string {
-	return s
+	return string(s)
 }
 
 // PrincipalSid is a Sid implementation holding a principal
@@ -24,20 +30,116 @@ type PrincipalSid string
 // Equals will check if the receiver is equal to provided Sid implementation
 func (p PrincipalSid) Equals(other acl.Sid) bool {
 	if o, ok := other.(PrincipalSid); ok {
-		return p.principal == o.principal
+		return p == o
 	}
 	return false
 }
 
 // GetPrincipal retrieve the principal for the receiver principal Sid.
 func (p PrincipalSid) GetPrincipal() string {
-	return p
+	return string(p)
+}
+
+// RoleHierarchy resolves every authority transitively implied by holding role, mirroring Spring Security's
+// RoleHierarchy: an edge "ROLE_ADMIN > ROLE_USER" means a principal granted ROLE_ADMIN also reaches every authority
+// ROLE_USER reaches.
+type RoleHierarchy interface {
+	// GetReachableAuthorities returns role itself plus every authority reachable by following hierarchy edges.
+	GetReachableAuthorities(role string) []string
+}
+
+// defaultRoleHierarchy is a DAG of role edges built once by NewRoleHierarchy. GetReachableAuthorities memoizes the
+// transitive reachable set of each role it is asked about, since a given hierarchy is queried far more often than
+// it changes.
+type defaultRoleHierarchy struct {
+	edges map[string][]string
+
+	mu    sync.Mutex
+	cache map[string][]string
+}
+
+// NewRoleHierarchy builds a RoleHierarchy from "ROLE_A > ROLE_B" rules, meaning a principal holding ROLE_A also
+// reaches every authority ROLE_B reaches. It returns an error if any rule is malformed or the edges form a cycle.
+func NewRoleHierarchy(rules ...string) (RoleHierarchy, error) {
+	h := &defaultRoleHierarchy{edges: make(map[string][]string), cache: make(map[string][]string)}
+	for _, rule := range rules {
+		parts := strings.SplitN(rule, ">", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("domain: malformed role hierarchy rule %q, expected \"ROLE_A > ROLE_B\"", rule)
+		}
+		higher, lower := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if higher == "" || lower == "" {
+			return nil, fmt.Errorf("domain: malformed role hierarchy rule %q, expected \"ROLE_A > ROLE_B\"", rule)
+		}
+		h.edges[higher] = append(h.edges[higher], lower)
+	}
+	if cycle := h.findCycle(); cycle != "" {
+		return nil, fmt.Errorf("domain: role hierarchy has a cycle reachable from %q", cycle)
+	}
+	return h, nil
+}
+
+// findCycle returns a role from which a cycle is reachable (not necessarily a role on the cycle itself), or "" if
+// the hierarchy is acyclic.
+func (h *defaultRoleHierarchy) findCycle() string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(h.edges))
+	var visit func(role string) bool
+	visit = func(role string) bool {
+		switch state[role] {
+		case visiting:
+			return true
+		case done:
+			return false
+		}
+		state[role] = visiting
+		for _, lower := range h.edges[role] {
+			if visit(lower) {
+				return true
+			}
+		}
+		state[role] = done
+		return false
+	}
+	for role := range h.edges {
+		if visit(role) {
+			return role
+		}
+	}
+	return ""
+}
+
+// GetReachableAuthorities returns role plus every authority transitively reachable from it, memoized per role.
+func (h *defaultRoleHierarchy) GetReachableAuthorities(role string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if cached, ok := h.cache[role]; ok {
+		return cached
+	}
+	seen := map[string]bool{role: true}
+	reachable := []string{role}
+	for i := 0; i < len(reachable); i++ {
+		for _, lower := range h.edges[reachable[i]] {
+			if !seen[lower] {
+				seen[lower] = true
+				reachable = append(reachable, lower)
+			}
+		}
+	}
+	h.cache[role] = reachable
+	return reachable
 }
 
 // DefaultSidRetrievalStrategy is a basic implementation of SidRetrievalStrategy that creates a Sid for the principal, as
 // well as every granted authority the principal holds.
 type DefaultSidRetrievalStrategy struct {
-	// TODO optionally provide a role hierarchy
+	// Hierarchy, when set, expands each granted authority into every authority it transitively implies before
+	// GetSids emits AuthoritySid values for it. A nil Hierarchy leaves authorities unexpanded.
+	Hierarchy RoleHierarchy
 }
 
 // NewDefaultSidRetrievalStrategy will create a new SidRetrievalStrategyImpl instance
@@ -45,13 +147,31 @@ func NewDefaultSidRetrievalStrategy() *DefaultSidRetrievalStrategy {
 	return &DefaultSidRetrievalStrategy{}
 }
 
+// WithRoleHierarchy attaches hierarchy to s so GetSids expands granted authorities through it. Returns s for
+// chaining.
+func (s *DefaultSidRetrievalStrategy) WithRoleHierarchy(hierarchy RoleHierarchy) *DefaultSidRetrievalStrategy {
+	s.Hierarchy = hierarchy
+	return s
+}
+
 // GetSids will retrieve the sids for given authentication object
 func (s *DefaultSidRetrievalStrategy) GetSids(auth acl.Authentication) []acl.Sid {
 	roles := auth.GetAuthorities()
-	sids := make([]acl.Sid, len(roles)+1)
+	sids := make([]acl.Sid, 0, len(roles)+1)
 	sids = append(sids, PrincipalSid(auth.GetPrincipal()))
+	seen := make(map[string]bool, len(roles))
 	for _, role := range roles {
-		sids = append(sids, AuthoritySid(role))
+		authorities := []string{role}
+		if s.Hierarchy != nil {
+			authorities = s.Hierarchy.GetReachableAuthorities(role)
+		}
+		for _, authority := range authorities {
+			if seen[authority] {
+				continue
+			}
+			seen[authority] = true
+			sids = append(sids, AuthoritySid(authority))
+		}
 	}
 	return sids
 }
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/streamtune/acl"
+)
+
+type stubAuthentication struct {
+	principal   string
+	authorities []string
+}
+
+func (a stubAuthentication) GetPrincipal() string     { return a.principal }
+func (a stubAuthentication) GetAuthorities() []string { return a.authorities }
+func (a stubAuthentication) GetAccessorID() string    { return a.principal }
+
+func TestDefaultSidRetrievalStrategyGetSids(t *testing.T) {
+	s := NewDefaultSidRetrievalStrategy()
+	auth := stubAuthentication{principal: "alice", authorities: []string{"ROLE_USER", "ROLE_ADMIN"}}
+
+	sids := s.GetSids(auth)
+
+	want := []acl.Sid{PrincipalSid("alice"), AuthoritySid("ROLE_USER"), AuthoritySid("ROLE_ADMIN")}
+	if len(sids) != len(want) {
+		t.Fatalf("GetSids returned %d sids, want %d: %v", len(sids), len(want), sids)
+	}
+	for i, s := range sids {
+		if !s.Equals(want[i]) {
+			t.Errorf("sids[%d] = %v, want %v", i, s, want[i])
+		}
+	}
+}
+
+func TestDefaultSidRetrievalStrategyGetSidsNoAuthorities(t *testing.T) {
+	s := NewDefaultSidRetrievalStrategy()
+	auth := stubAuthentication{principal: "alice"}
+
+	sids := s.GetSids(auth)
+
+	if len(sids) != 1 || !sids[0].Equals(PrincipalSid("alice")) {
+		t.Fatalf("GetSids with no authorities = %v, want [PrincipalSid(alice)]", sids)
+	}
+}
+
+func TestDefaultSidRetrievalStrategyWithRoleHierarchyExpandsAndDedupes(t *testing.T) {
+	hierarchy, err := NewRoleHierarchy("ROLE_ADMIN > ROLE_USER", "ROLE_USER > ROLE_GUEST")
+	if err != nil {
+		t.Fatalf("NewRoleHierarchy: %v", err)
+	}
+	s := NewDefaultSidRetrievalStrategy().WithRoleHierarchy(hierarchy)
+	auth := stubAuthentication{principal: "alice", authorities: []string{"ROLE_ADMIN", "ROLE_USER"}}
+
+	sids := s.GetSids(auth)
+
+	want := []acl.Sid{
+		PrincipalSid("alice"),
+		AuthoritySid("ROLE_ADMIN"),
+		AuthoritySid("ROLE_USER"),
+		AuthoritySid("ROLE_GUEST"),
+	}
+	if len(sids) != len(want) {
+		t.Fatalf("GetSids returned %v, want %v", sids, want)
+	}
+	for i, s := range sids {
+		if !s.Equals(want[i]) {
+			t.Errorf("sids[%d] = %v, want %v", i, s, want[i])
+		}
+	}
+}
+
+func TestRoleHierarchyGetReachableAuthorities(t *testing.T) {
+	hierarchy, err := NewRoleHierarchy("ROLE_ADMIN > ROLE_USER", "ROLE_USER > ROLE_GUEST")
+	if err != nil {
+		t.Fatalf("NewRoleHierarchy: %v", err)
+	}
+
+	got := hierarchy.GetReachableAuthorities("ROLE_ADMIN")
+	want := []string{"ROLE_ADMIN", "ROLE_USER", "ROLE_GUEST"}
+	if len(got) != len(want) {
+		t.Fatalf("GetReachableAuthorities(ROLE_ADMIN) = %v, want %v", got, want)
+	}
+	for i, r := range got {
+		if r != want[i] {
+			t.Errorf("got[%d] = %q, want %q", i, r, want[i])
+		}
+	}
+}
+
+func TestNewRoleHierarchyRejectsCycle(t *testing.T) {
+	_, err := NewRoleHierarchy("ROLE_A > ROLE_B", "ROLE_B > ROLE_A")
+	if err == nil {
+		t.Fatal("expected an error for a cyclic role hierarchy")
+	}
+}
+
+func TestNewRoleHierarchyRejectsMalformedRule(t *testing.T) {
+	_, err := NewRoleHierarchy("ROLE_A ROLE_B")
+	if err == nil {
+		t.Fatal("expected an error for a malformed rule with no '>' separator")
+	}
+}
@@ -0,0 +1,67 @@
+package acl
+
+import "testing"
+
+type stubAuthentication struct {
+	principal   string
+	authorities []string
+}
+
+func (a stubAuthentication) GetPrincipal() string     { return a.principal }
+func (a stubAuthentication) GetAuthorities() []string { return a.authorities }
+func (a stubAuthentication) GetAccessorID() string    { return a.principal }
+
+func TestDefaultSidRetriever(t *testing.T) {
+	auth := stubAuthentication{principal: "alice", authorities: []string{"ROLE_USER", "ROLE_ADMIN"}}
+
+	sids := DefaultSidRetriever(auth)
+
+	want := []Sid{PrincipalSid("alice"), AuthoritySid("ROLE_USER"), AuthoritySid("ROLE_ADMIN")}
+	if len(sids) != len(want) {
+		t.Fatalf("DefaultSidRetriever returned %d sids, want %d: %v", len(sids), len(want), sids)
+	}
+	for i, s := range sids {
+		if s == nil {
+			t.Fatalf("sids[%d] is nil, want %v", i, want[i])
+		}
+		if !s.Equals(want[i]) {
+			t.Errorf("sids[%d] = %v, want %v", i, s, want[i])
+		}
+	}
+}
+
+func TestDefaultSidRetrieverNoAuthorities(t *testing.T) {
+	auth := stubAuthentication{principal: "alice"}
+
+	sids := DefaultSidRetriever(auth)
+
+	if len(sids) != 1 || sids[0] == nil || !sids[0].Equals(PrincipalSid("alice")) {
+		t.Fatalf("DefaultSidRetriever with no authorities = %v, want a single non-nil PrincipalSid", sids)
+	}
+}
+
+// allowLegacyAuthorizer is a LegacyAuthorizer that permits every SecurityCheck, so legacyAcl mutator tests can
+// exercise the mutation itself without depending on DefaultAuthorizer's own ownership/authority logic.
+type allowLegacyAuthorizer struct{}
+
+func (allowLegacyAuthorizer) SecurityCheck(Authentication, LegacyAcl, ChangeType) error { return nil }
+
+func TestLegacyAclUpdateAuditingReportsSuccess(t *testing.T) {
+	o := NewObjectIdentity("Doc", 1)
+	a, err := newLegacyAcl(o, 1, allowLegacyAuthorizer{}, DefaultPermissionGranter, nil, nil, nil, false, PrincipalSid("owner"))
+	if err != nil {
+		t.Fatalf("newLegacyAcl: %v", err)
+	}
+	if err := a.InsertAce(nil, 0, ReadPermission, PrincipalSid("alice"), true, HardMandatory, nil); err != nil {
+		t.Fatalf("InsertAce: %v", err)
+	}
+
+	if err := a.UpdateAuditing(nil, 0, true, true); err != nil {
+		t.Fatalf("UpdateAuditing reported an error on a successful update: %v", err)
+	}
+
+	ace := a.aces[0].(*legacyAccessControlEntry)
+	if !ace.IsAuditSuccess() || !ace.IsAuditFailure() {
+		t.Fatal("expected UpdateAuditing to set both audit flags")
+	}
+}
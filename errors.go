@@ -0,0 +1,35 @@
+package acl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PermissionDeniedError is returned whenever a principal is refused an authorization decision. It carries enough
+// structured context - who was checked, what was requested and against which resource - so that callers can build
+// meaningful audit trails or branch on the denial reason instead of matching on an error string.
+//
+// Accessor is typically the sid.Sid that was checked, Permission is typically the change.Type or
+// permission.Permission (or a slice of them) that was requested, and Oid is typically the oid.Oid identifying the
+// resource being accessed. They are declared as interface{} because this error is shared by several Acl/Authorizer
+// implementations that do not all agree on a single concrete Sid/Permission/Oid type.
+type PermissionDeniedError struct {
+	Accessor   interface{}
+	Permission interface{}
+	Oid        interface{}
+	Cause      string
+}
+
+// Error implements the error interface.
+func (e *PermissionDeniedError) Error() string {
+	if e.Cause != "" {
+		return fmt.Sprintf("Permission denied: accessor=%v required=%v resource=%v: %s", e.Accessor, e.Permission, e.Oid, e.Cause)
+	}
+	return fmt.Sprintf("Permission denied: accessor=%v required=%v resource=%v", e.Accessor, e.Permission, e.Oid)
+}
+
+// IsPermissionDenied reports whether err is, or wraps, a *PermissionDeniedError.
+func IsPermissionDenied(err error) bool {
+	var target *PermissionDeniedError
+	return errors.As(err, &target)
+}
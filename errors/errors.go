@@ -0,0 +1,65 @@
+// Package errors provides structured errors shared by the ACL authorizers, so that callers can branch on why a
+// request was denied instead of matching on an error string.
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+// Reason discriminates why a PermissionDeniedError was returned.
+type Reason int
+
+const (
+	// ReasonDeniedByACL means a principal was resolved and a required permission was evaluated against the ACL, but
+	// no entry (nor the absence of one) granted it.
+	ReasonDeniedByACL Reason = iota
+	// ReasonUnsupportedChange means the requested change.Type/security.ChangeType has no configured authority.
+	ReasonUnsupportedChange
+	// ReasonNoPrincipal means no authenticated principal could be found in the calling context.
+	ReasonNoPrincipal
+)
+
+// PermissionDeniedError is returned whenever a principal is refused an authorization decision. It carries the
+// acting SID/accessor, the target resource (type + id), the access level that was required, and why the
+// decision was a denial, so callers can discriminate "denied by ACL" from "denied by change type" or "denied
+// because no principal was in context" without string matching.
+type PermissionDeniedError struct {
+	Accessor sid.Sid
+	Resource oid.Oid
+	Required permission.Permission
+	Reason   Reason
+	Cause    string
+}
+
+// Error implements the error interface.
+func (e *PermissionDeniedError) Error() string {
+	return e.String()
+}
+
+// String renders the error as "Permission denied: accessor=... resource=type:id required=...".
+func (e *PermissionDeniedError) String() string {
+	accessor := "<none>"
+	if e.Accessor != nil {
+		accessor = e.Accessor.Name()
+	}
+	resource := "<none>"
+	if e.Resource != nil {
+		resource = fmt.Sprintf("%s:%v", e.Resource.Type(), e.Resource.Identifier())
+	}
+	msg := fmt.Sprintf("Permission denied: accessor=%s resource=%s required=%s", accessor, resource, e.Required)
+	if e.Cause != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Cause)
+	}
+	return msg
+}
+
+// IsErrPermissionDenied reports whether err is, or wraps, a *PermissionDeniedError.
+func IsErrPermissionDenied(err error) bool {
+	var target *PermissionDeniedError
+	return stderrors.As(err, &target)
+}
@@ -0,0 +1,242 @@
+// Package filter provides generics-based helpers for authorizing a slice of arbitrary domain objects in one round
+// trip instead of hand-rolling a per-item IsGranted loop around a list endpoint, mirroring what Consul factored out
+// into its own acl/filter package.
+package filter
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/streamtune/acl"
+	"github.com/streamtune/acl/audit"
+	"github.com/streamtune/acl/errors"
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+// Mode selects what Filter does when the current principal is not granted perms on an item.
+type Mode int
+
+const (
+	// Silent drops unauthorized items from the result, returning no error.
+	Silent Mode = iota
+	// Strict aborts and returns a *errors.PermissionDeniedError on the first unauthorized item.
+	Strict
+)
+
+// Filterer is implemented by Service backends (typically database-backed ones) that can push an authorization
+// filter down into their own storage layer instead of loading every ACL into memory, e.g. translating it into a
+// single "WHERE oid IN (...) AND sid IN (...)" query. Filter prefers it over IsGrantedBatch whenever svc implements
+// it.
+type Filterer interface {
+	FilterGranted(ctx context.Context, oids []oid.Oid, perms []permission.Permission, sids []sid.Sid) (granted map[oid.Oid]bool, err error)
+}
+
+// cacheKey identifies one cached (oid, sids, perms) authorization decision. sids and perms are pre-sorted, joined
+// strings rather than the raw slices so Cache's index map can use cacheKey as a plain comparable map key.
+type cacheKey struct {
+	oid   oid.Oid
+	sids  string
+	perms string
+}
+
+type cacheEntry struct {
+	key     cacheKey
+	granted bool
+}
+
+// Cache is a small bounded LRU of (oid, sids, perms) decisions that FilterWithOptions consults before calling the
+// Service at all, short-circuiting repeat lookups for the same principal/permission set across calls, e.g. paging
+// through the same list view. It is safe for concurrent use.
+type Cache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	index map[cacheKey]*list.Element
+}
+
+// NewCache returns a Cache bounded to at most size entries. A size of zero disables the bound.
+func NewCache(size int) *Cache {
+	return &Cache{size: size, order: list.New(), index: make(map[cacheKey]*list.Element)}
+}
+
+func (c *Cache) get(key cacheKey) (bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.index[key]
+	if !ok {
+		return false, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).granted, true
+}
+
+func (c *Cache) put(key cacheKey, granted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.index[key]; ok {
+		elem.Value.(*cacheEntry).granted = granted
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.index[key] = c.order.PushFront(&cacheEntry{key: key, granted: granted})
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.index, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func sidsKey(sids []sid.Sid) string {
+	names := make([]string, len(sids))
+	for i, s := range sids {
+		names[i] = s.Name()
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func permsKey(perms []permission.Permission) string {
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = p.String()
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// FilterOptions adds optional behavior to FilterWithOptions: a Cache to short-circuit repeat (oid, sids, perms)
+// lookups across calls, and an Auditor to record one audit event per denied resource rather than one per
+// (permission, sid) tuple the underlying Service may have evaluated to reach that decision.
+type FilterOptions struct {
+	Cache   *Cache
+	Auditor audit.Auditor
+}
+
+// Filter authorizes items against svc in a single batched ReadAclsById/IsGrantedBatch call and returns only those
+// the current principal (resolved from ctx via sid.Retrieve) is granted perms on. oidOf must return the oid.Oid
+// that identifies item within the ACL subsystem. Parent-chain grouping, so an inherited ACL is evaluated only once
+// for every item that shares it, is the responsibility of the Service.IsGrantedBatch implementation Filter calls
+// into (see DefaultIsGrantedBatch).
+//
+// In Silent mode, unauthorized items are dropped from the result. In Strict mode, the first unauthorized item
+// aborts the whole call with a *errors.PermissionDeniedError. Input order is preserved.
+func Filter[T any](ctx context.Context, svc acl.Service, items []T, oidOf func(T) oid.Oid, perms []permission.Permission, mode Mode) ([]T, error) {
+	return FilterWithOptions(ctx, svc, items, oidOf, perms, mode, nil)
+}
+
+// FilterWithOptions is like Filter but accepts FilterOptions for caching and audit-batching. A nil opts behaves
+// exactly like Filter. When svc also implements Filterer, it is preferred over IsGrantedBatch for any item whose
+// decision was not already found in opts.Cache.
+func FilterWithOptions[T any](ctx context.Context, svc acl.Service, items []T, oidOf func(T) oid.Oid, perms []permission.Permission, mode Mode, opts *FilterOptions) ([]T, error) {
+	sids, err := sid.Retrieve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	oids := make([]oid.Oid, len(items))
+	for i, item := range items {
+		oids[i] = oidOf(item)
+	}
+
+	var cache *Cache
+	var auditor audit.Auditor
+	if opts != nil {
+		cache = opts.Cache
+		auditor = opts.Auditor
+	}
+	sk, pk := sidsKey(sids), permsKey(perms)
+
+	granted := make(map[oid.Oid]bool, len(oids))
+	errs := make(map[oid.Oid]error)
+	uncached := oids
+	if cache != nil {
+		uncached = nil
+		for _, o := range oids {
+			if decision, ok := cache.get(cacheKey{oid: o, sids: sk, perms: pk}); ok {
+				granted[o] = decision
+				continue
+			}
+			uncached = append(uncached, o)
+		}
+	}
+
+	if len(uncached) > 0 {
+		var fresh map[oid.Oid]bool
+		if pushdown, ok := svc.(Filterer); ok {
+			if fresh, err = pushdown.FilterGranted(ctx, uncached, perms, sids); err != nil {
+				return nil, err
+			}
+		} else {
+			var batchErrs map[oid.Oid]error
+			if fresh, batchErrs, err = svc.IsGrantedBatch(ctx, uncached, perms, sids); err != nil {
+				return nil, err
+			}
+			for o, cause := range batchErrs {
+				errs[o] = cause
+			}
+		}
+		for _, o := range uncached {
+			decision := fresh[o]
+			granted[o] = decision
+			if cache != nil {
+				if _, failed := errs[o]; !failed {
+					cache.put(cacheKey{oid: o, sids: sk, perms: pk}, decision)
+				}
+			}
+		}
+	}
+
+	result := make([]T, 0, len(items))
+	for i, item := range items {
+		o := oids[i]
+		if cause, ok := errs[o]; ok {
+			if mode == Strict {
+				return nil, cause
+			}
+			continue
+		}
+		if !granted[o] {
+			if auditor != nil {
+				// One audit event per denied resource, regardless of how many (permission, sid) tuples the Service
+				// evaluated internally to reach that decision.
+				auditor.Audit(false, deniedResource{o})
+			}
+			if mode == Strict {
+				var required permission.Permission
+				if len(perms) > 0 {
+					required = perms[0]
+				}
+				var accessor sid.Sid
+				if len(sids) > 0 {
+					accessor = sids[0]
+				}
+				return nil, &errors.PermissionDeniedError{
+					Accessor: accessor,
+					Resource: o,
+					Required: required,
+					Reason:   errors.ReasonDeniedByACL,
+				}
+			}
+			continue
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// deniedResource adapts an oid.Oid to audit.Auditable so FilterWithOptions can report a denial via an audit.Auditor
+// without a concrete Ace to hand it, always auditing as a failure.
+type deniedResource struct {
+	oid oid.Oid
+}
+
+func (deniedResource) IsAuditSuccess() bool { return false }
+func (deniedResource) IsAuditFailure() bool { return true }
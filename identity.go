@@ -0,0 +1,137 @@
+package acl
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/streamtune/acl/sid"
+)
+
+// Identity carries the outcome of resolving "who is making this request" without yet deciding what they may do.
+// AccessorID is a stable, safe-to-log identifier for the principal (e.g. a username or a token's accessor id),
+// while SecretID is the opaque credential that was presented (e.g. a bearer token or API key) and must never be
+// written to logs or audit trails.
+type Identity struct {
+	AccessorID  string
+	SecretID    string
+	Principal   string
+	Authorities []string
+}
+
+// IdentityProvider resolves the Identity of the caller carried by ctx, without deciding which sid.Sid it maps to.
+// This separates "who is the principal" (identity resolution, pluggable per token scheme) from "what may they do"
+// (authorization, handled by Authorizer/Checker), so integrators can plug in OIDC, Vault or their own token store
+// without reimplementing the ACL walk.
+type IdentityProvider interface {
+	ResolveIdentity(ctx context.Context) (Identity, error)
+}
+
+// PolicyResolver maps a resolved Identity to the set of Sid a principal holds, mirroring sid.Retriever but operating
+// on an already-resolved Identity instead of reaching back into the context itself.
+type PolicyResolver func(Identity) ([]sid.Sid, error)
+
+// DefaultPolicyResolver is the PolicyResolver used when none is configured: it maps Identity.Principal to a
+// sid.ForPrincipal and each of Identity.Authorities to a sid.ForAuthority.
+func DefaultPolicyResolver(identity Identity) ([]sid.Sid, error) {
+	sids := make([]sid.Sid, 0, len(identity.Authorities)+1)
+	principal, err := sid.ForPrincipal(identity.Principal)
+	if err != nil {
+		return nil, err
+	}
+	sids = append(sids, principal)
+	for _, authority := range identity.Authorities {
+		s, err := sid.ForAuthority(authority)
+		if err != nil {
+			return nil, err
+		}
+		sids = append(sids, s)
+	}
+	return sids, nil
+}
+
+// StaticIdentityProvider resolves identities from a fixed, in-memory table of secret -> Identity. It is most useful
+// for tests, bootstrap phases and internal system calls.
+type StaticIdentityProvider map[string]Identity
+
+// ResolveIdentity looks the secret carried by ctx (under the "Secret" key) up in the table.
+func (p StaticIdentityProvider) ResolveIdentity(ctx context.Context) (Identity, error) {
+	secret, ok := ctx.Value("Secret").(string)
+	if !ok {
+		return Identity{}, errors.New("no secret found on context")
+	}
+	identity, ok := p[secret]
+	if !ok {
+		return Identity{}, fmt.Errorf("no identity registered for secret")
+	}
+	return identity, nil
+}
+
+// JWTIdentityProvider resolves identities from an HMAC-SHA256-signed JWT bearer token carried by ctx (under the
+// "Bearer" key), mapping the standard "sub" claim to Principal/AccessorID and a "authorities" claim to Authorities.
+type JWTIdentityProvider struct {
+	// Key is the shared secret used to verify the token's HS256 signature.
+	Key []byte
+}
+
+// NewJWTIdentityProvider creates a JWTIdentityProvider verifying tokens with key.
+func NewJWTIdentityProvider(key []byte) *JWTIdentityProvider {
+	return &JWTIdentityProvider{Key: key}
+}
+
+type jwtClaims struct {
+	Subject     string   `json:"sub"`
+	Authorities []string `json:"authorities"`
+}
+
+// ResolveIdentity parses and verifies the bearer token, returning the Identity carried by its claims.
+func (p *JWTIdentityProvider) ResolveIdentity(ctx context.Context) (Identity, error) {
+	token, ok := ctx.Value("Bearer").(string)
+	if !ok {
+		return Identity{}, errors.New("no bearer token found on context")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Identity{}, errors.New("malformed JWT: expected three dot-separated parts")
+	}
+	signingInput := parts[0] + "." + parts[1]
+	mac := hmac.New(sha256.New, p.Key)
+	mac.Write([]byte(signingInput))
+	expected := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return Identity{}, errors.New("JWT signature verification failed")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Identity{}, fmt.Errorf("malformed JWT payload: %w", err)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Identity{}, fmt.Errorf("malformed JWT claims: %w", err)
+	}
+	return Identity{AccessorID: claims.Subject, SecretID: token, Principal: claims.Subject, Authorities: claims.Authorities}, nil
+}
+
+// ChainedIdentityProvider tries each of its providers in order, returning the first successful resolution.
+type ChainedIdentityProvider []IdentityProvider
+
+// ResolveIdentity tries each provider in order, returning the last error if all of them fail.
+func (c ChainedIdentityProvider) ResolveIdentity(ctx context.Context) (Identity, error) {
+	var err error
+	for _, provider := range c {
+		var identity Identity
+		identity, err = provider.ResolveIdentity(ctx)
+		if err == nil {
+			return identity, nil
+		}
+	}
+	if err == nil {
+		err = errors.New("no identity provider configured")
+	}
+	return Identity{}, err
+}
@@ -0,0 +1,80 @@
+// Package identity resolves bearer secrets into the Authentication consumed by acl.DefaultAuthorizer, keeping the
+// stable, safe-to-log handle for a token (its AccessorID) separate from the opaque secret that was presented to
+// obtain it - mirroring the Consul token accessor/secret split.
+package identity
+
+import (
+	"fmt"
+
+	"github.com/streamtune/acl"
+)
+
+// Identity is the resolved outcome of exchanging a secret for "who is making this request". AccessorID is a
+// stable identifier for the token itself and is safe to log; SecretID is the opaque credential that produced it
+// and must never be written to logs or audit trails. Local marks identities resolved without reaching an external
+// store, such as bootstrap or anonymous tokens.
+type Identity struct {
+	AccessorID  string
+	SecretID    string
+	Principal   string
+	Authorities []string
+	Local       bool
+}
+
+// TokenResolver exchanges a secret for the Identity it was issued to.
+type TokenResolver interface {
+	Resolve(secret string) (Identity, error)
+}
+
+// InMemoryResolver resolves secrets from a fixed, in-memory table. It is most useful for tests, bootstrap phases
+// and internal system calls; production deployments should back TokenResolver with their own token store.
+type InMemoryResolver map[string]Identity
+
+// Resolve looks secret up in the table.
+func (r InMemoryResolver) Resolve(secret string) (Identity, error) {
+	identity, ok := r[secret]
+	if !ok {
+		return Identity{}, fmt.Errorf("no identity registered for secret")
+	}
+	return identity, nil
+}
+
+// Authenticator resolves a bearer secret into the acl.Authentication consumed by
+// acl.DefaultAuthorizer.SecurityCheck, via a pluggable TokenResolver.
+type Authenticator struct {
+	resolver TokenResolver
+}
+
+// NewAuthenticator creates an Authenticator resolving secrets through resolver.
+func NewAuthenticator(resolver TokenResolver) *Authenticator {
+	return &Authenticator{resolver: resolver}
+}
+
+// Authenticate resolves secret and adapts the Identity it maps to into an acl.Authentication.
+func (a *Authenticator) Authenticate(secret string) (acl.Authentication, error) {
+	identity, err := a.resolver.Resolve(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &authentication{identity}, nil
+}
+
+// authentication adapts a resolved Identity to the acl.Authentication interface.
+type authentication struct {
+	identity Identity
+}
+
+// GetPrincipal retrieves the principal carried by the resolved identity.
+func (a *authentication) GetPrincipal() string {
+	return a.identity.Principal
+}
+
+// GetAuthorities retrieves the authorities carried by the resolved identity.
+func (a *authentication) GetAuthorities() []string {
+	return a.identity.Authorities
+}
+
+// GetAccessorID retrieves the safe-to-log accessor id for the token that was resolved, never its secret.
+func (a *authentication) GetAccessorID() string {
+	return a.identity.AccessorID
+}
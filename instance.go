@@ -0,0 +1,61 @@
+package acl
+
+import "context"
+
+// Instance is the contract the domain subpackage's Acl implementation exposes: enough to let a
+// PermissionGrantingStrategy walk an ACL's entries and its parent chain without depending on the richer,
+// context-aware Acl or the legacy LegacyAcl.
+type Instance interface {
+	// GetEntries returns the Ace instances held directly by this Instance. Entries associated with a parent are not
+	// included.
+	GetEntries() []InstanceAce
+
+	// GetIdentity obtains the domain object this Instance provides entries for.
+	GetIdentity() Oid
+
+	// GetOwner determines the owner of this Instance.
+	GetOwner() Sid
+
+	// GetParent returns the parent Instance for inheritance purposes, or nil if there is none.
+	GetParent() Instance
+
+	// IsEntriesInheriting indicates whether the entries of GetParent should flow down into this Instance.
+	IsEntriesInheriting() bool
+
+	// IsGranted is the authorization decision method: it reports whether sids hold one of permissions against this
+	// Instance.
+	IsGranted(permissions []Permission, sids []Sid, admin bool) (bool, error)
+}
+
+// InstanceAce is the minimal access-control-entry contract consumed through Instance.GetEntries: just enough for a
+// PermissionGrantingStrategy to match permission, Sid and grant/deny, without requiring the Enforcement/Scope
+// extensions Ace (Checker's richer variant) and LegacyAce (the model.go/domain.go variant) each add for their own
+// tracks.
+type InstanceAce interface {
+	// GetID obtains an identifier that represents this InstanceAce.
+	GetID() interface{}
+
+	// GetAcl retrieves the owning Instance.
+	GetAcl() Instance
+
+	// GetPermission obtains the permission of this InstanceAce.
+	GetPermission() Permission
+
+	// GetSid obtains the Sid for this InstanceAce.
+	GetSid() Sid
+
+	// IsGranting indicates the permission is being granted to the relevant Sid. If false, indicates the permission
+	// is being revoked/blocked.
+	IsGranting() bool
+}
+
+// PermissionGrantingStrategy decides whether sids hold one of permissions against instance, given resource as the
+// domain object (or request context) the caller is attempting to act on.
+type PermissionGrantingStrategy interface {
+	IsGranted(ctx context.Context, instance Instance, permissions []Permission, sids []Sid, admin bool, resource interface{}) (bool, error)
+}
+
+// SidRetrievalStrategy resolves the Sids applicable to an authenticated principal.
+type SidRetrievalStrategy interface {
+	GetSids(Authentication) []Sid
+}
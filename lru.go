@@ -0,0 +1,187 @@
+package acl
+
+import (
+	"container/list"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/streamtune/acl/oid"
+)
+
+// LRUStats are the counters exposed by an LRUCache, suitable for periodic publishing to Prometheus, expvar, or any
+// other metrics sink operators use to tune size/ttl.
+type LRUStats struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	Size      int64
+}
+
+type lruEntry struct {
+	id        interface{}
+	oid       oid.Oid
+	acl       MutableAcl
+	expiresAt time.Time
+}
+
+// lruCache is a bounded, TTL-expiring Cache. Both the id-index and the oid-index point at the same *list.Element so
+// that evicting an entry, whether by capacity (LRU) or by ttl, always removes it from both indices together and
+// never leaves a stale read behind in either one.
+type lruCache struct {
+	mu sync.Mutex
+
+	size int
+	ttl  time.Duration
+
+	order  *list.List // front = most recently used
+	byID   map[interface{}]*list.Element
+	byOid  map[oid.Oid]*list.Element
+	hits   expvar.Int
+	misses expvar.Int
+	evicts expvar.Int
+}
+
+// NewLRUCache returns a Cache bounded to at most size entries, each of which expires ttl after it was put in cache.
+// A size or ttl of zero disables that particular bound. Eviction, whether triggered by capacity or expiry, always
+// removes the entry from both the id-index and the oid-index so the two indices can never disagree about what is
+// cached.
+func NewLRUCache(size int, ttl time.Duration) Cache {
+	return &lruCache{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		byID:  make(map[interface{}]*list.Element),
+		byOid: make(map[oid.Oid]*list.Element),
+	}
+}
+
+// Stats returns a snapshot of the cache's hit/miss/eviction counters and its current size.
+func (c *lruCache) Stats() LRUStats {
+	c.mu.Lock()
+	size := int64(c.order.Len())
+	c.mu.Unlock()
+	return LRUStats{
+		Hits:      c.hits.Value(),
+		Misses:    c.misses.Value(),
+		Evictions: c.evicts.Value(),
+		Size:      size,
+	}
+}
+
+func (c *lruCache) expired(e *lruEntry) bool {
+	return c.ttl > 0 && time.Now().After(e.expiresAt)
+}
+
+// removeElement removes elem from the order list and both indices. Caller must hold c.mu.
+func (c *lruCache) removeElement(elem *list.Element) {
+	entry := elem.Value.(*lruEntry)
+	c.order.Remove(elem)
+	delete(c.byID, entry.id)
+	delete(c.byOid, entry.oid)
+}
+
+func (c *lruCache) evictOldestLocked() {
+	oldest := c.order.Back()
+	if oldest == nil {
+		return
+	}
+	c.removeElement(oldest)
+	c.evicts.Add(1)
+}
+
+func (c *lruCache) lookupLocked(elem *list.Element) (MutableAcl, bool) {
+	entry := elem.Value.(*lruEntry)
+	if c.expired(entry) {
+		c.removeElement(elem)
+		c.evicts.Add(1)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.acl, true
+}
+
+func (c *lruCache) GetFromCacheByID(id interface{}) (MutableAcl, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.byID[id]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	acl, ok := c.lookupLocked(elem)
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return acl, true
+}
+
+func (c *lruCache) GetFromCacheByOid(o oid.Oid) (MutableAcl, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.byOid[o]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	acl, ok := c.lookupLocked(elem)
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return acl, true
+}
+
+func (c *lruCache) PutInCache(acl MutableAcl) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := acl.GetID()
+	o := acl.GetIdentity()
+
+	if elem, ok := c.byID[id]; ok {
+		c.removeElement(elem)
+	}
+	if elem, ok := c.byOid[o]; ok {
+		c.removeElement(elem)
+	}
+
+	entry := &lruEntry{id: id, oid: o, acl: acl}
+	if c.ttl > 0 {
+		entry.expiresAt = time.Now().Add(c.ttl)
+	}
+	elem := c.order.PushFront(entry)
+	c.byID[id] = elem
+	c.byOid[o] = elem
+
+	for c.size > 0 && c.order.Len() > c.size {
+		c.evictOldestLocked()
+	}
+}
+
+func (c *lruCache) EvictFromCacheByID(id interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.byID[id]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *lruCache) EvictFromCacheByOid(o oid.Oid) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.byOid[o]; ok {
+		c.removeElement(elem)
+	}
+}
+
+func (c *lruCache) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.byID = make(map[interface{}]*list.Element)
+	c.byOid = make(map[oid.Oid]*list.Element)
+}
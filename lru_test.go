@@ -0,0 +1,117 @@
+package acl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamtune/acl/oid"
+)
+
+// newTestMutableAcl builds a minimal MutableAcl for exercising the cache in isolation, independent of any
+// particular Authorizer/Checker behavior.
+func newTestMutableAcl(t *testing.T, id interface{}, kind string) MutableAcl {
+	t.Helper()
+	o, err := oid.Generate(id, kind)
+	if err != nil {
+		t.Fatalf("oid.Generate: %v", err)
+	}
+	auth, err := NewAuthorizer("ROLE_ADMIN", "ROLE_AUDIT", "ROLE_OWNERSHIP")
+	if err != nil {
+		t.Fatalf("NewAuthorizer: %v", err)
+	}
+	a, err := newAcl(id, o, auth, DefaultChecker(), nil, nil, false, nil)
+	if err != nil {
+		t.Fatalf("newAcl: %v", err)
+	}
+	return a
+}
+
+func TestLRUCachePutAndGet(t *testing.T) {
+	c := NewLRUCache(0, 0)
+	a := newTestMutableAcl(t, 1, "Doc")
+
+	c.PutInCache(a)
+
+	if got, ok := c.GetFromCacheByID(1); !ok || got != a {
+		t.Fatalf("GetFromCacheByID(1) = %v, %v; want %v, true", got, ok, a)
+	}
+	if got, ok := c.GetFromCacheByOid(a.GetIdentity()); !ok || got != a {
+		t.Fatalf("GetFromCacheByOid = %v, %v; want %v, true", got, ok, a)
+	}
+}
+
+func TestLRUCacheEvictsOnCapacity(t *testing.T) {
+	c := NewLRUCache(2, 0).(*lruCache)
+	a1 := newTestMutableAcl(t, 1, "Doc")
+	a2 := newTestMutableAcl(t, 2, "Doc")
+	a3 := newTestMutableAcl(t, 3, "Doc")
+
+	c.PutInCache(a1)
+	c.PutInCache(a2)
+	c.PutInCache(a3) // should evict a1, the least recently used
+
+	if _, ok := c.GetFromCacheByID(1); ok {
+		t.Fatal("expected entry 1 to have been evicted once the cache exceeded its size")
+	}
+	if _, ok := c.GetFromCacheByID(2); !ok {
+		t.Fatal("expected entry 2 to still be cached")
+	}
+	if _, ok := c.GetFromCacheByID(3); !ok {
+		t.Fatal("expected entry 3 to still be cached")
+	}
+	if stats := c.Stats(); stats.Evictions != 1 {
+		t.Errorf("Evictions = %d, want 1", stats.Evictions)
+	}
+}
+
+func TestLRUCacheGetRefreshesRecency(t *testing.T) {
+	c := NewLRUCache(2, 0).(*lruCache)
+	a1 := newTestMutableAcl(t, 1, "Doc")
+	a2 := newTestMutableAcl(t, 2, "Doc")
+	a3 := newTestMutableAcl(t, 3, "Doc")
+
+	c.PutInCache(a1)
+	c.PutInCache(a2)
+	c.GetFromCacheByID(1) // touch 1, making 2 the least recently used
+	c.PutInCache(a3)      // should evict 2, not 1
+
+	if _, ok := c.GetFromCacheByID(2); ok {
+		t.Fatal("expected entry 2 to have been evicted as the least recently used")
+	}
+	if _, ok := c.GetFromCacheByID(1); !ok {
+		t.Fatal("expected entry 1 to still be cached after being refreshed by a Get")
+	}
+}
+
+func TestLRUCacheExpiresByTTL(t *testing.T) {
+	c := NewLRUCache(0, time.Millisecond)
+	a := newTestMutableAcl(t, 1, "Doc")
+	c.PutInCache(a)
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.GetFromCacheByID(1); ok {
+		t.Fatal("expected entry to have expired after its ttl elapsed")
+	}
+	if _, ok := c.GetFromCacheByOid(a.GetIdentity()); ok {
+		t.Fatal("expected the oid index to agree with the id index that the entry expired")
+	}
+}
+
+func TestLRUCacheEvictAndClear(t *testing.T) {
+	c := NewLRUCache(0, 0)
+	a1 := newTestMutableAcl(t, 1, "Doc")
+	a2 := newTestMutableAcl(t, 2, "Doc")
+	c.PutInCache(a1)
+	c.PutInCache(a2)
+
+	c.EvictFromCacheByID(1)
+	if _, ok := c.GetFromCacheByID(1); ok {
+		t.Fatal("expected entry 1 to be gone after EvictFromCacheByID")
+	}
+
+	c.ClearCache()
+	if _, ok := c.GetFromCacheByID(2); ok {
+		t.Fatal("expected ClearCache to remove every remaining entry")
+	}
+}
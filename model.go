@@ -5,11 +5,11 @@ import (
 	"errors"
 )
 
-// ErrSidUnloaded is returned when an Acl cannot perform an operation because the caller has requested Sid not loaded.
+// ErrSidUnloaded is returned when an LegacyAcl cannot perform an operation because the caller has requested Sid not loaded.
 var (
-	ErrNotFound       = errors.New("No Acl found")
-	ErrExists         = errors.New("An Acl already exists for provided object identity")
-	ErrChildrenExists = errors.New("Acl cannot be deleted because a children ACL exists")
+	ErrNotFound       = errors.New("No LegacyAcl found")
+	ErrExists         = errors.New("An LegacyAcl already exists for provided object identity")
+	ErrChildrenExists = errors.New("LegacyAcl cannot be deleted because a children ACL exists")
 	ErrSidUnloaded    = errors.New("Requested SID was not loaded")
 )
 
@@ -17,12 +17,18 @@ var (
 type Authentication interface {
 	GetPrincipal() string
 	GetAuthorities() []string
+
+	// GetAccessorID returns a stable, safe-to-log identifier for the token/session that produced this
+	// Authentication, distinct from whatever secret was exchanged to resolve it (see identity.Identity). Audit
+	// trails must record this instead of the secret, so a denial can be traced back to a specific token without
+	// leaking credentials.
+	GetAccessorID() string
 }
 
 // Sid is a security identity recognised by the ACL system.
 //
 // Thi interface provides indirection between actual security object (e.g. principals, roles, groups etc.) and what is
-// stored inside an Acl. This is because an Acl will not store an entire security object, but only an abstraction of it.
+// stored inside an LegacyAcl. This is because an LegacyAcl will not store an entire security object, but only an abstraction of it.
 // This interface therefore provides a simple way to compare these abstracted security identities with other security
 // identities and actual security objects.
 type Sid interface {
@@ -90,14 +96,21 @@ func (p Permission) String() string {
 	return res.String()
 }
 
-// AuditLogger is used in order to audit logging data.
-type AuditLogger func(bool, Ace)
+// EnforcementLevel and its HardMandatory/SoftMandatory/Advisory constants are declared in acl.go; a denying
+// LegacyAce's Enforcement() uses the same levels Checker.Check reacts to.
+
+// OverrideAuthorizer decides whether an override token entitles accessor to bypass a SoftMandatory denial. It is
+// consulted by DefaultPermissionGranter only after a SoftMandatory LegacyAce would otherwise reject the request.
+type OverrideAuthorizer interface {
+	AuthorizeOverride(accessor, token string) bool
+}
 
 // PermissionGranter allow customization of the logic for determining whether a permission or permissions are
-// granted to a particular Sid or Sids by an Acl.
-type PermissionGranter func(Acl, []Permission, []Sid, bool, AuditLogger) (bool, error)
+// granted to a particular Sid or Sids by an LegacyAcl. admin requests should be granted a Noop Auditor rather than have
+// callers special-case suppressing individual Audit calls.
+type PermissionGranter func(acl LegacyAcl, permissions []Permission, sids []Sid, admin bool, subject interface{}, auditor Auditor) (bool, error)
 
-// Oid represents the identity of an individual domain object Acl.
+// Oid represents the identity of an individual domain object LegacyAcl.
 type Oid interface {
 	// Obtains the actual identifier. This identifier must not be reused to represent other domain objects with the same
 	// type.
@@ -117,7 +130,7 @@ type Oid interface {
 // OidGenerator is the strategy which creates an ObjectIdentity from an object identifier (such as a primary
 // key) and type information.
 //
-// Differs from ObjectIdentityRetrievalStrategy in that it is used in situations when the actual object Acl isn't
+// Differs from ObjectIdentityRetrievalStrategy in that it is used in situations when the actual object LegacyAcl isn't
 // available.
 type OidGenerator func(interface{}, string) (Oid, error)
 
@@ -125,45 +138,45 @@ type OidGenerator func(interface{}, string) (Oid, error)
 // returned for a particular domain object.
 type OidRetriever func(interface{}) (Oid, error)
 
-// Acl represents an access control list for a domain object.
+// LegacyAcl represents an access control list for a domain object.
 //
-// An Acl represents all ACL entries for a given domain object. In order to avoid needing references to the domain
+// An LegacyAcl represents all ACL entries for a given domain object. In order to avoid needing references to the domain
 // object itself, this interface handles indirection between a domain object and an ACL object identity via the
 // ObjectIdentity.
 //
 // Implementing classes may elect to return Acls that represent Permission information for either some OR all Sid
-// Acls. Therefore, an Acl may NOT necessarily contain ALL Sids for a given domain object.
-type Acl interface {
-	// Returns all of the entries represented by the present Acl. Entries associated with the Acl parents are not
+// Acls. Therefore, an LegacyAcl may NOT necessarily contain ALL Sids for a given domain object.
+type LegacyAcl interface {
+	// Returns all of the entries represented by the present LegacyAcl. Entries associated with the LegacyAcl parents are not
 	// returned.
 	//
 	// This method is typically used for administrative purposes.
 	//
-	// The order that entries apper in the array is important for methods declared in the MutableAcl interface.
+	// The order that entries apper in the array is important for methods declared in the LegacyMutableAcl interface.
 	// Furthermore, some implementations MAY use ordering as part of advanced permission checking.
 	//
 	// Do NOT use this method for making authorization decisions. Instead use IsGranted.
 	//
-	// This method must operate correctly even if the Acl only represents a subset of Sids. The caller is responsible
+	// This method must operate correctly even if the LegacyAcl only represents a subset of Sids. The caller is responsible
 	// for correctly handling the result if only a subset of Sids is represented.
-	GetEntries() []Ace
+	GetEntries() []LegacyAce
 
-	// Obtains the domain object this Acl provides entries for. This is immutable once an Acl is created.
+	// Obtains the domain object this LegacyAcl provides entries for. This is immutable once an LegacyAcl is created.
 	GetIdentity() Oid
 
-	// Determines the owner of the Acl. The meaning of ownership varies by implementation and is unspecified.
+	// Determines the owner of the LegacyAcl. The meaning of ownership varies by implementation and is unspecified.
 	GetOwner() Sid
 
 	// A domain object may have a prent for the purpose of ACL inheritance. If there is a parent, its ACL can be
 	// accessed via this method. In turn, the parent's parent (grandparent) can be accessed and so on.
 	//
-	// This method solely represents the presence of a navigation hierarchy between the parent Acl and this Acl. For
+	// This method solely represents the presence of a navigation hierarchy between the parent LegacyAcl and this LegacyAcl. For
 	// actual inheritance to take place, the IsEntriesInheriting must also be true.
-	GetParent() Acl
+	GetParent() LegacyAcl
 
-	// Indicates whether the ACL entries from the GetParentAcl should flow down into the current Acl.
+	// Indicates whether the ACL entries from the GetParentAcl should flow down into the current LegacyAcl.
 	//
-	// The mere link between an Acl and a parent Acl on its own is insufficient to cause ACL entries to inherit down.
+	// The mere link between an LegacyAcl and a parent LegacyAcl on its own is insufficient to cause ACL entries to inherit down.
 	// This is because a domain object may wish to have entirely independent entries, but maintain the link with the
 	// parent for navigation purposes. Thus, this method denotes whether or not the navigation relationship also extends
 	// to the actual inheritance of entries.
@@ -186,66 +199,72 @@ type Acl interface {
 	// true, the authorization decision may be passed to the parent ACL. If there is no matching entry, the
 	// implementation MAY return an error, or make a predefined authorization decision.
 	//
-	// This method must operate correctly even if the Acl only represents a subset of Sids, although the implementation
+	// This method must operate correctly even if the LegacyAcl only represents a subset of Sids, although the implementation
 	// is permitted to throw one of the signature-defined exceptions if the method is called requesting an
-	// authorization decision for a Sid that was never loaded in this Acl.
-	IsGranted([]Permission, []Sid, bool) (bool, error)
+	// authorization decision for a Sid that was never loaded in this LegacyAcl.
+	//
+	// subject is the domain object (or request context) the caller is attempting to act on. It is passed unchanged
+	// to the Scope of any granting LegacyAce that has one, so callers not using scoped ACEs may simply pass nil.
+	IsGranted(permissions []Permission, sids []Sid, admin bool, subject interface{}) (bool, error)
 
-	// For efficiency reasons an Acl may be loaded and not contain entries for every Sid in the system.
-	// If an Acl has been loaded and does not represent every Sid, all methods of the Acl can only be used within the
+	// For efficiency reasons an LegacyAcl may be loaded and not contain entries for every Sid in the system.
+	// If an LegacyAcl has been loaded and does not represent every Sid, all methods of the LegacyAcl can only be used within the
 	// limited scope of the Sid Acls it actually represents.
 	//
-	// It is normal to load an Acl for only particular Sids if read-only authorization decisions are being made.
-	// However, if user interface reporting or modification of Acls are desired, an Acl should be loaded with all
+	// It is normal to load an LegacyAcl for only particular Sids if read-only authorization decisions are being made.
+	// However, if user interface reporting or modification of Acls are desired, an LegacyAcl should be loaded with all
 	// Sids. This method denotes whether or not the specified Sids have been loaded or not.
 	IsSidLoaded([]Sid) bool
 }
 
-// MutableAcl represents a mutable ACL.
+// LegacyMutableAcl represents a mutable ACL.
 //
 // A mutable ACL must ensure that appropriate security checks are performed before allowing access to its methods.
-type MutableAcl interface {
-	Acl
+// Every mutator takes the resolved Authentication of the caller as its first argument, so the security check it
+// runs before mutating is performed against a real principal rather than a placeholder.
+type LegacyMutableAcl interface {
+	LegacyAcl
 
-	// Obtains an identifier that represents this MutableAcl
+	// Obtains an identifier that represents this LegacyMutableAcl
 	GetID() interface{}
 
 	// Changes the present owner to a different one.
-	SetOwner(Sid) error
+	SetOwner(Authentication, Sid) error
 
 	// Change the value returned by IsEntriesInheriting
-	SetEntriesInheriting(bool) error
+	SetEntriesInheriting(Authentication, bool) error
 
 	// Changes the parent of this ACL.
-	SetParent(Acl) error
+	SetParent(Authentication, LegacyAcl) error
 
-	// Inserts a new AccessControlEntry at provided index.
-	InsertAce(int, Permission, Sid, bool) error
+	// Inserts a new AccessControlEntry at provided index, enforced at the given EnforcementLevel and, when scope is
+	// non-nil, gated to subjects scope accepts.
+	InsertAce(auth Authentication, index int, permission Permission, sid Sid, granting bool, enforcement EnforcementLevel, scope ScopeFn) error
 
-	// Updates the permission of AccessControlEntry at provided index.
-	UpdateAce(int, Permission) error
+	// Updates the permission and EnforcementLevel of AccessControlEntry at provided index.
+	UpdateAce(Authentication, int, Permission, EnforcementLevel) error
 
 	// Deletes the AccessControlEntry at provided index.
-	DeleteAce(int) error
+	DeleteAce(Authentication, int) error
 }
 
-// AuditableAcl is a MutableAcl that allows auditing capabilities.
-type AuditableAcl interface {
-	MutableAcl
+// LegacyAuditableAcl is a LegacyMutableAcl that allows auditing capabilities.
+type LegacyAuditableAcl interface {
+	LegacyMutableAcl
 
 	// Update auditing flags for AccessControlEntry at index
-	UpdateAuditing(index int, success, failure bool) error
+	UpdateAuditing(auth Authentication, index int, success, failure bool) error
 }
 
-// Ace represents an individual permission assignment within an Acl.
+// LegacyAce represents an individual permission assignment within an LegacyAcl.
 //
-// Acls MUST be immutable, as they are returned by Acl and should not allow client modification.
-type Ace interface {
+// Acls MUST be immutable, as they are returned by LegacyAcl and should not allow client modification.
+type LegacyAce interface {
 	// Obtains an indetifier that represents this ACE.
 	GetID() interface{}
 
-	// Retrieve the owning Acl
-	GetAcl() Acl
+	// Retrieve the owning LegacyAcl
+	GetAcl() LegacyAcl
 
 	// Obtains the permission of this ACE
 	GetPermission() Permission
@@ -256,49 +275,57 @@ type Ace interface {
 	// Indicates the permission is being granted to the relevant Sid. If false, indicates the permission is being
 	// revoked/blocked.
 	IsGranting() bool
+
+	// Enforcement reports how a denial by this LegacyAce affects IsGranted: HardMandatory rejects outright, SoftMandatory
+	// rejects unless overridden, Advisory never rejects.
+	Enforcement() EnforcementLevel
+
+	// Scope returns the ScopeFn, if any, that gates whether this LegacyAce's permission applies to the subject passed to
+	// IsGranted. A nil result means the LegacyAce applies unconditionally.
+	Scope() ScopeFn
 }
 
-// AuditableAce is an AccessControlEntry that provides auditing indications
-type AuditableAce interface {
-	Ace
+// LegacyAuditableAce is an AccessControlEntry that provides auditing indications
+type LegacyAuditableAce interface {
+	LegacyAce
 
 	IsAuditSuccess() bool
 
 	IsAuditFailure() bool
 }
 
-// Cache represents a caching layer for Service.
-type Cache interface {
+// LegacyCache represents a caching layer for LegacyService.
+type LegacyCache interface {
 	EvictFromCache(id interface{})
 
-	GetFromCache(id interface{}) MutableAcl
+	GetFromCache(id interface{}) LegacyMutableAcl
 
-	PutInCache(acl MutableAcl)
+	PutInCache(acl LegacyMutableAcl)
 
 	ClearCache()
 }
 
-// Service is the interface that provides retrieval of Acl Acls.
-type Service interface {
+// LegacyService is the interface that provides retrieval of LegacyAcl Acls.
+type LegacyService interface {
 	// Locates all object identities that use the specified parent. This is useful for administration tools.
 	FindChildren(oid Oid) []Oid
 
 	// Reads a single ACL for the given object identity and (optionally) the list of sid.
-	ReadAclById(oid Oid, sids []Sid) (Acl, error)
+	ReadAclById(oid Oid, sids []Sid) (LegacyAcl, error)
 
-	// Obtains all the Acl that apply for the passed in object identities and (optionally) the list of sid.
-	ReadAclsById(oids []Oid, sids []Sid) (map[Oid]Acl, error)
+	// Obtains all the LegacyAcl that apply for the passed in object identities and (optionally) the list of sid.
+	ReadAclsById(oids []Oid, sids []Sid) (map[Oid]LegacyAcl, error)
 }
 
-// MutableService is the interface that provides updates of Acl Acls.
-type MutableService interface {
-	Service
+// LegacyMutableService is the interface that provides updates of LegacyAcl Acls.
+type LegacyMutableService interface {
+	LegacyService
 
-	// Creates an empty Acl object. It will have no entries. The returnes object will then be used to add entries.
-	CreateAcl(oid Oid) (MutableAcl, error)
+	// Creates an empty LegacyAcl object. It will have no entries. The returnes object will then be used to add entries.
+	CreateAcl(oid Oid) (LegacyMutableAcl, error)
 
-	// Updates an existing Acl.
-	UpdateAcl(acl MutableAcl) (MutableAcl, error)
+	// Updates an existing LegacyAcl.
+	UpdateAcl(acl LegacyMutableAcl) (LegacyMutableAcl, error)
 
 	// Removes the specified entry from the backend storage.
 	DeleteAcl(oid Oid, children bool) error
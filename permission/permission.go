@@ -20,6 +20,17 @@ const (
 	AdministrationPermission
 )
 
+// Any is a Permission that matches every requested permission bit. Binding an Ace to Any grants (or denies, if the
+// Ace is non-granting) the bound Sid regardless of which Permission was actually requested.
+const Any Permission = ^Permission(0)
+
+// Wildcard returns the Permission that matches any requested permission, mirroring sid.Wildcard's "matches
+// anything" idiom on the permission side. It is currently just Any under another name, kept distinct so callers can
+// say what they mean ("any permission will do") without reaching for the bit-pattern constant directly.
+func Wildcard() Permission {
+	return Any
+}
+
 // Match will check that a permission match another one
 func (p Permission) Match(other Permission) bool {
 	return p&other != 0
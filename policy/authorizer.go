@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+// Authorizer consults compiled Policies by principal/authority name, independent of any per-object Ace, so that a
+// prefix grant such as "com.example.Doc/*" authorizes a request even when no ACE was ever materialized for the
+// concrete object. It is meant to be consulted alongside an existing Authorizer/Checker, not to replace it: callers
+// should fall through to per-ACE evaluation when Authorize returns false.
+type Authorizer struct {
+	policies   map[string]*Policy
+	management map[string]bool
+}
+
+// NewAuthorizer creates an empty Authorizer. Use Grant and GrantManagement to populate it.
+func NewAuthorizer() *Authorizer {
+	return &Authorizer{
+		policies:   make(map[string]*Policy),
+		management: make(map[string]bool),
+	}
+}
+
+// Grant attaches p to name (a sid.Sid's Name()), merging it with any Policy already granted to that name.
+func (a *Authorizer) Grant(name string, p *Policy) {
+	if existing, ok := a.policies[name]; ok {
+		a.policies[name] = Merge(existing, p)
+	} else {
+		a.policies[name] = p
+	}
+}
+
+// GrantManagement marks name as a management principal: Authorize always returns true for it, short-circuiting
+// policy evaluation the same way an administration permission would.
+func (a *Authorizer) GrantManagement(name string) {
+	a.management[name] = true
+}
+
+// Authorize reports whether any of sids is granted required over o. A Sid's compiled Policy can grant required
+// directly, or hold management rights; but an explicit "deny" rule (Policy.Denied) in that same Policy always wins
+// over both, so a narrower deny layered on top of a broader grant or management right is never bypassed.
+func (a *Authorizer) Authorize(sids []sid.Sid, o oid.Oid, required permission.Permission) bool {
+	for _, s := range sids {
+		p, ok := a.policies[s.Name()]
+		if ok && p.Denied(o).Match(required) {
+			continue
+		}
+		if a.management[s.Name()] {
+			return true
+		}
+		if ok && p.Capabilities(o).Match(required) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+// Package policy lets administrators grant capabilities by object-identity prefix (e.g. "every Doc under project
+// foo") instead of materializing one Ace per object, mirroring the prefix-based ACL evaluation model used by tools
+// like Nomad. A Policy compiles a Document into a trie keyed by oid.Oid type/identifier segments; looking a Policy
+// up for a given oid.Oid walks the longest matching prefix and unions the capabilities granted by every ancestor on
+// the way, so a grant on "Doc/*" also covers a more specific "Doc/42/attachments/*" rule layered on top of it.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+)
+
+// Document is the policy document format compiled by Compile. Administrators describe grants as a list of Objects,
+// each binding a set of permission names to an object-identity type and a name (or, when its last path segment is
+// "*", a path prefix).
+type Document struct {
+	Objects []ObjectGrant `json:"objects"`
+}
+
+// ObjectGrant grants Permissions over every object of Type whose name matches Name. Name is split on "/"; a segment
+// that is exactly "*" matches any segment at that position and everything beneath it, so "projects/foo/*" covers
+// every object under projects/foo and the bare string "*" matches every object of Type. A "*" inside a longer
+// segment (e.g. "projects/fo*") is matched literally, not as a prefix.
+type ObjectGrant struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+var permissionNames = map[string]permission.Permission{
+	"read":           permission.ReadPermission,
+	"write":          permission.WritePermission,
+	"create":         permission.CreatePermisssion,
+	"delete":         permission.DeletePermission,
+	"administration": permission.AdministrationPermission,
+	"list":           permission.ReadPermission,
+}
+
+// node is a single level of the compiled prefix trie. A child keyed "*" denotes a wildcard: it matches any segment
+// (and everything beneath it) that was not matched by a more specific child.
+type node struct {
+	children     map[string]*node
+	capabilities permission.Permission
+	// denials holds the permission bits an explicit "deny" policy rule (see ParseRules) revokes at this path. It
+	// takes precedence over capabilities granted at or above the same path: see Policy.Denied.
+	denials permission.Permission
+}
+
+func newNode() *node {
+	return &node{children: make(map[string]*node)}
+}
+
+func (n *node) insert(segments []string, caps, denials permission.Permission) {
+	if len(segments) == 0 {
+		n.capabilities |= caps
+		n.denials |= denials
+		return
+	}
+	child, ok := n.children[segments[0]]
+	if !ok {
+		child = newNode()
+		n.children[segments[0]] = child
+	}
+	child.insert(segments[1:], caps, denials)
+}
+
+// lookup unions the given field of every node along the longest matching path, including any "*" wildcard
+// encountered, which covers everything beneath it regardless of how deep segments continues.
+func (n *node) lookup(segments []string, field func(*node) permission.Permission) permission.Permission {
+	caps := permission.NoPermission
+	if wc, ok := n.children["*"]; ok {
+		caps |= field(wc)
+	}
+	if len(segments) == 0 {
+		return caps
+	}
+	if child, ok := n.children[segments[0]]; ok {
+		caps |= field(child)
+		caps |= child.lookup(segments[1:], field)
+	}
+	return caps
+}
+
+// Policy is a compiled Document: a capability trie that can be queried in O(len(path)) per oid.Oid lookup.
+type Policy struct {
+	root *node
+}
+
+// Compile parses doc and builds the prefix trie backing Capabilities and Denied.
+func Compile(doc Document) (*Policy, error) {
+	root := newNode()
+	for _, object := range doc.Objects {
+		if object.Type == "" {
+			return nil, fmt.Errorf("policy: object grant is missing a type")
+		}
+		var caps, denials permission.Permission
+		for _, name := range object.Permissions {
+			name = strings.ToLower(name)
+			if name == denyPolicy {
+				denials |= permission.Any
+				continue
+			}
+			flag, ok := permissionNames[name]
+			if !ok {
+				return nil, fmt.Errorf("policy: unknown permission %q", name)
+			}
+			caps |= flag
+		}
+		segments := append([]string{object.Type}, pathSegments(object.Name)...)
+		root.insert(segments, caps, denials)
+	}
+	return &Policy{root: root}, nil
+}
+
+// CompileJSON parses a JSON-encoded Document and compiles it, e.g.:
+//
+//	{"objects": [{"type": "com.example.Doc", "name": "*", "permissions": ["read", "write"]}]}
+func CompileJSON(data []byte) (*Policy, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("policy: invalid document: %w", err)
+	}
+	return Compile(doc)
+}
+
+func pathSegments(name string) []string {
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, "/")
+}
+
+// Capabilities returns the union of every capability this Policy grants for o, by walking the longest matching
+// prefix of o's type and identifier and unioning the capabilities of every ancestor (including wildcards) visited
+// along the way.
+func (p *Policy) Capabilities(o oid.Oid) permission.Permission {
+	return p.root.lookup(p.segments(o), func(n *node) permission.Permission { return n.capabilities })
+}
+
+// Denied returns the union of every permission an explicit "deny" rule (see ParseRules) revokes for o, walking the
+// same longest-matching-prefix path as Capabilities. A bit set in Denied always wins over the same bit set in
+// Capabilities, regardless of which rule is more specific.
+func (p *Policy) Denied(o oid.Oid) permission.Permission {
+	return p.root.lookup(p.segments(o), func(n *node) permission.Permission { return n.denials })
+}
+
+func (p *Policy) segments(o oid.Oid) []string {
+	return append([]string{o.Type()}, pathSegments(fmt.Sprintf("%v", o.Identifier()))...)
+}
+
+// Merge combines multiple Policies into one whose Capabilities and Denied are each the union of all of them for
+// any given oid.Oid. A deny from any of policies therefore carries over unchanged: explicit deny always wins.
+func Merge(policies ...*Policy) *Policy {
+	merged := &Policy{root: newNode()}
+	for _, p := range policies {
+		mergeNode(merged.root, p.root)
+	}
+	return merged
+}
+
+func mergeNode(dst, src *node) {
+	dst.capabilities |= src.capabilities
+	dst.denials |= src.denials
+	for key, child := range src.children {
+		dstChild, ok := dst.children[key]
+		if !ok {
+			dstChild = newNode()
+			dst.children[key] = dstChild
+		}
+		mergeNode(dstChild, child)
+	}
+}
@@ -0,0 +1,194 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+func mustSid(t *testing.T, name string) sid.Sid {
+	t.Helper()
+	s, err := sid.ForPrincipal(name)
+	if err != nil {
+		t.Fatalf("sid.ForPrincipal: %v", err)
+	}
+	return s
+}
+
+func mustOid(t *testing.T, kind string, id interface{}) oid.Oid {
+	t.Helper()
+	o, err := oid.Generate(id, kind)
+	if err != nil {
+		t.Fatalf("oid.Generate: %v", err)
+	}
+	return o
+}
+
+func TestPolicyWildcardGrantsEveryObjectOfType(t *testing.T) {
+	p, err := Compile(Document{Objects: []ObjectGrant{
+		{Type: "Doc", Name: "*", Permissions: []string{"read"}},
+	}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	if !p.Capabilities(mustOid(t, "Doc", 42)).Match(permission.ReadPermission) {
+		t.Fatal("expected the Doc/* grant to cover every Doc, including id 42")
+	}
+	if p.Capabilities(mustOid(t, "Folder", 42)).Match(permission.ReadPermission) {
+		t.Fatal("expected the Doc/* grant not to cover a different object type")
+	}
+}
+
+func TestPolicyPrefixPrecedenceUnionsAncestors(t *testing.T) {
+	p, err := Compile(Document{Objects: []ObjectGrant{
+		{Type: "Doc", Name: "*", Permissions: []string{"read"}},
+		{Type: "Doc", Name: "projects/foo/*", Permissions: []string{"write"}},
+	}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	caps := p.Capabilities(mustOid(t, "Doc", "projects/foo/42"))
+	if !caps.Match(permission.ReadPermission) {
+		t.Error("expected the broader Doc/* grant to still apply to a more specific prefix match")
+	}
+	if !caps.Match(permission.WritePermission) {
+		t.Error("expected the more specific projects/foo/* grant to apply")
+	}
+
+	other := p.Capabilities(mustOid(t, "Doc", "projects/bar/42"))
+	if other.Match(permission.WritePermission) {
+		t.Error("expected the projects/foo/* grant not to leak into projects/bar")
+	}
+	if !other.Match(permission.ReadPermission) {
+		t.Error("expected the Doc/* grant to still cover projects/bar")
+	}
+}
+
+func TestPolicyDenyWinsOverBroaderGrant(t *testing.T) {
+	p, err := Compile(Document{Objects: []ObjectGrant{
+		{Type: "Doc", Name: "*", Permissions: []string{"read", "write"}},
+		{Type: "Doc", Name: "projects/foo/*", Permissions: []string{"deny"}},
+	}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	o := mustOid(t, "Doc", "projects/foo/42")
+	if !p.Capabilities(o).Match(permission.ReadPermission) {
+		t.Fatal("expected Capabilities to still report the broader grant")
+	}
+	if !p.Denied(o).Match(permission.ReadPermission) {
+		t.Fatal("expected the narrower deny rule to cover this object")
+	}
+}
+
+func TestCompileRejectsUnknownPermission(t *testing.T) {
+	_, err := Compile(Document{Objects: []ObjectGrant{
+		{Type: "Doc", Name: "*", Permissions: []string{"fly"}},
+	}})
+	if err == nil {
+		t.Fatal("expected an error for an unknown permission name")
+	}
+}
+
+func TestCompileRejectsMissingType(t *testing.T) {
+	_, err := Compile(Document{Objects: []ObjectGrant{
+		{Name: "*", Permissions: []string{"read"}},
+	}})
+	if err == nil {
+		t.Fatal("expected an error for an object grant missing a type")
+	}
+}
+
+func TestMergeUnionsCapabilitiesAndKeepsDeny(t *testing.T) {
+	a, err := Compile(Document{Objects: []ObjectGrant{{Type: "Doc", Name: "*", Permissions: []string{"read"}}}})
+	if err != nil {
+		t.Fatalf("Compile a: %v", err)
+	}
+	b, err := Compile(Document{Objects: []ObjectGrant{
+		{Type: "Doc", Name: "*", Permissions: []string{"write"}},
+		{Type: "Doc", Name: "secret/*", Permissions: []string{"deny"}},
+	}})
+	if err != nil {
+		t.Fatalf("Compile b: %v", err)
+	}
+
+	merged := Merge(a, b)
+	if !merged.Capabilities(mustOid(t, "Doc", 1)).Match(permission.ReadPermission | permission.WritePermission) {
+		t.Fatal("expected Merge to union capabilities from both policies")
+	}
+	if !merged.Denied(mustOid(t, "Doc", "secret/1")).Match(permission.ReadPermission) {
+		t.Fatal("expected Merge to carry over a deny rule from either policy")
+	}
+}
+
+func TestParseRulesAndCompileRules(t *testing.T) {
+	p, err := CompileRules(`
+resource "Doc" "projects/foo/*" {
+  policy = "read"
+}
+resource "Doc" "projects/foo/secret" {
+  policy = "deny"
+}
+`)
+	if err != nil {
+		t.Fatalf("CompileRules: %v", err)
+	}
+	if !p.Capabilities(mustOid(t, "Doc", "projects/foo/42")).Match(permission.ReadPermission) {
+		t.Fatal("expected the parsed read rule to grant access")
+	}
+	if !p.Denied(mustOid(t, "Doc", "projects/foo/secret")).Match(permission.ReadPermission) {
+		t.Fatal("expected the parsed deny rule to be recorded")
+	}
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	p, err := Compile(Document{Objects: []ObjectGrant{{Type: "Doc", Name: "*", Permissions: []string{"read"}}}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	a := NewAuthorizer()
+	a.Grant("alice", p)
+
+	sids := []sid.Sid{mustSid(t, "alice")}
+	if !a.Authorize(sids, mustOid(t, "Doc", 1), permission.ReadPermission) {
+		t.Fatal("expected alice's granted Policy to authorize the read")
+	}
+	if a.Authorize(sids, mustOid(t, "Doc", 1), permission.WritePermission) {
+		t.Fatal("expected alice's Policy not to authorize a write it was never granted")
+	}
+
+	other := []sid.Sid{mustSid(t, "bob")}
+	if a.Authorize(other, mustOid(t, "Doc", 1), permission.ReadPermission) {
+		t.Fatal("expected an un-granted principal not to be authorized")
+	}
+}
+
+func TestAuthorizerManagementShortCircuits(t *testing.T) {
+	a := NewAuthorizer()
+	a.GrantManagement("admin")
+
+	sids := []sid.Sid{mustSid(t, "admin")}
+	if !a.Authorize(sids, mustOid(t, "Doc", 1), permission.AdministrationPermission) {
+		t.Fatal("expected a management principal to be authorized for any permission")
+	}
+}
+
+func TestAuthorizerDenyWinsOverManagement(t *testing.T) {
+	p, err := Compile(Document{Objects: []ObjectGrant{{Type: "Doc", Name: "secret/*", Permissions: []string{"deny"}}}})
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	a := NewAuthorizer()
+	a.GrantManagement("admin")
+	a.Grant("admin", p)
+
+	sids := []sid.Sid{mustSid(t, "admin")}
+	if a.Authorize(sids, mustOid(t, "Doc", "secret/1"), permission.ReadPermission) {
+		t.Fatal("expected an explicit deny to win even over a management grant")
+	}
+}
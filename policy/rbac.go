@@ -0,0 +1,238 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Context carries runtime attributes (e.g. resolved subject/resource fields) consulted by a Condition when
+// deciding whether a matching Grant actually applies, e.g. {"owner": doc.OwnerID, "requester": subject.ID}.
+type Context map[string]interface{}
+
+// Condition is a predicate evaluated against ctx to decide whether a matching Grant actually applies.
+type Condition func(ctx Context) (bool, error)
+
+// Subject identifies the accessor attempting an action, along with the roles it holds.
+type Subject struct {
+	ID    string
+	Roles []string
+}
+
+// Resource identifies the object an action is performed against.
+type Resource struct {
+	Type string
+	ID   string
+}
+
+// Grant binds a set of Actions over resources of Type to Role, optionally gated by Conditions, all of which must
+// pass for the grant to apply. Type "*" matches any resource type, and an Action of "*" matches any action.
+type Grant struct {
+	Role       string
+	Type       string
+	Actions    []string
+	Conditions []Condition
+}
+
+func (g Grant) allows(action string) bool {
+	for _, a := range g.Actions {
+		if a == "*" || a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (g Grant) evaluate(ctx Context) (bool, error) {
+	for _, cond := range g.Conditions {
+		ok, err := cond(ctx)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// PolicyProvider loads Grants from a backing store (in-memory, file, or anything else callers implement).
+type PolicyProvider interface {
+	Load() ([]Grant, error)
+}
+
+// StaticProvider is a PolicyProvider backed by an in-memory slice of Grant.
+type StaticProvider []Grant
+
+// Load returns p unchanged.
+func (p StaticProvider) Load() ([]Grant, error) {
+	return []Grant(p), nil
+}
+
+// FileProvider loads Grants from a JSON document at Path shaped like:
+//
+//	[{"role": "editor", "type": "com.example.Doc", "actions": ["read", "write"]}]
+//
+// Conditions cannot be expressed in the JSON document and must be attached programmatically after Load, e.g. via
+// PolicyManager.Grant.
+type FileProvider struct {
+	Path string
+}
+
+// Load reads and parses the JSON document at p.Path.
+func (p FileProvider) Load() ([]Grant, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, fmt.Errorf("policy: reading %s: %w", p.Path, err)
+	}
+	var docs []struct {
+		Role    string   `json:"role"`
+		Type    string   `json:"type"`
+		Actions []string `json:"actions"`
+	}
+	if err := json.Unmarshal(data, &docs); err != nil {
+		return nil, fmt.Errorf("policy: parsing %s: %w", p.Path, err)
+	}
+	grants := make([]Grant, len(docs))
+	for i, d := range docs {
+		grants[i] = Grant{Role: d.Role, Type: d.Type, Actions: d.Actions}
+	}
+	return grants, nil
+}
+
+// Checker is consulted by PolicyManager.IsGranted whenever no Grant decides a request, typically wrapping the
+// existing ACE-level Checker of the acl package so policy-based and ACE-based decisions compose.
+type Checker interface {
+	Check(subject Subject, resource Resource, action string, ctx Context) (bool, error)
+}
+
+// PolicyManager layers declarative, condition-gated RBAC/ABAC Grants on top of an existing ACE-level Checker: a
+// request is granted if any loaded Grant matches the subject's roles, the resource type, and the action (subject to
+// its Conditions); otherwise the decision falls back to Fallback, so deployments keep their existing ACE-based
+// behavior unchanged until they start loading Grants.
+type PolicyManager struct {
+	grants   []Grant
+	Fallback Checker
+}
+
+// NewPolicyManager creates a PolicyManager that falls back to fallback (may be nil) whenever no Grant decides a
+// request.
+func NewPolicyManager(fallback Checker) *PolicyManager {
+	return &PolicyManager{Fallback: fallback}
+}
+
+// Load replaces the manager's Grants with those returned by provider.
+func (m *PolicyManager) Load(provider PolicyProvider) error {
+	grants, err := provider.Load()
+	if err != nil {
+		return err
+	}
+	m.grants = grants
+	return nil
+}
+
+// Grant appends g to the manager's Grants, e.g. to attach a Condition the JSON document format can't express.
+func (m *PolicyManager) Grant(g Grant) {
+	m.grants = append(m.grants, g)
+}
+
+// IsGranted reports whether subject may perform action on resource. Every loaded Grant whose Role is held by
+// subject and whose Type matches resource.Type is consulted in order; the first one whose Actions include action
+// and whose Conditions all pass grants the request. If no Grant decides it, the decision falls back to m.Fallback.
+func (m *PolicyManager) IsGranted(subject Subject, resource Resource, action string, ctx Context) (bool, error) {
+	for _, grant := range m.grants {
+		if grant.Type != resource.Type && grant.Type != "*" {
+			continue
+		}
+		if !hasRole(subject.Roles, grant.Role) {
+			continue
+		}
+		if !grant.allows(action) {
+			continue
+		}
+		ok, err := grant.evaluate(ctx)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	if m.Fallback != nil {
+		return m.Fallback.Check(subject, resource, action, ctx)
+	}
+	return false, nil
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role || r == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal builds a Condition requiring ctx[field] == value.
+func Equal(field string, value interface{}) Condition {
+	return func(ctx Context) (bool, error) {
+		v, ok := ctx[field]
+		return ok && v == value, nil
+	}
+}
+
+// HasValue builds a Condition requiring ctx[field] to be present and non-nil.
+func HasValue(field string) Condition {
+	return func(ctx Context) (bool, error) {
+		v, ok := ctx[field]
+		return ok && v != nil, nil
+	}
+}
+
+// GreaterThan builds a Condition requiring ctx[field] to be a number greater than value.
+func GreaterThan(field string, value float64) Condition {
+	return func(ctx Context) (bool, error) {
+		v, ok := ctx[field]
+		if !ok {
+			return false, nil
+		}
+		n, err := toFloat64(v)
+		if err != nil {
+			return false, err
+		}
+		return n > value, nil
+	}
+}
+
+func toFloat64(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("policy: value %v is not numeric", v)
+	}
+}
+
+// ConditionBuilder constructs a Condition from a field name and builder-specific arguments, letting callers
+// register custom predicates beyond the Equal/HasValue/GreaterThan built-ins.
+type ConditionBuilder func(field string, args ...interface{}) Condition
+
+var registeredConditions = make(map[string]ConditionBuilder)
+
+// RegisterCondition registers builder under name, so it can be looked up later via LookupCondition, e.g. by a
+// PolicyProvider that resolves named predicates from a document format.
+func RegisterCondition(name string, builder ConditionBuilder) {
+	registeredConditions[name] = builder
+}
+
+// LookupCondition returns the ConditionBuilder registered under name, if any.
+func LookupCondition(name string) (ConditionBuilder, bool) {
+	builder, ok := registeredConditions[name]
+	return builder, ok
+}
@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicyManagerGrantMatchesRoleTypeAndAction(t *testing.T) {
+	m := NewPolicyManager(nil)
+	m.Grant(Grant{Role: "editor", Type: "Doc", Actions: []string{"read", "write"}})
+
+	subject := Subject{ID: "alice", Roles: []string{"editor"}}
+	resource := Resource{Type: "Doc", ID: "42"}
+
+	granted, err := m.IsGranted(subject, resource, "write", nil)
+	if err != nil {
+		t.Fatalf("IsGranted: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected the editor Grant to authorize a write on a Doc")
+	}
+
+	if granted, _ := m.IsGranted(subject, resource, "delete", nil); granted {
+		t.Fatal("expected the editor Grant not to authorize an action it doesn't list")
+	}
+}
+
+func TestPolicyManagerWildcardTypeAndAction(t *testing.T) {
+	m := NewPolicyManager(nil)
+	m.Grant(Grant{Role: "admin", Type: "*", Actions: []string{"*"}})
+
+	subject := Subject{ID: "root", Roles: []string{"admin"}}
+	resource := Resource{Type: "Folder", ID: "1"}
+
+	granted, err := m.IsGranted(subject, resource, "delete", nil)
+	if err != nil {
+		t.Fatalf("IsGranted: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected a wildcard type/action Grant to authorize any resource/action")
+	}
+}
+
+func TestPolicyManagerConditionGatesGrant(t *testing.T) {
+	m := NewPolicyManager(nil)
+	m.Grant(Grant{Role: "owner", Type: "Doc", Actions: []string{"write"}, Conditions: []Condition{Equal("ownerID", "alice")}})
+
+	subject := Subject{ID: "alice", Roles: []string{"owner"}}
+	resource := Resource{Type: "Doc", ID: "42"}
+
+	if granted, _ := m.IsGranted(subject, resource, "write", Context{"ownerID": "bob"}); granted {
+		t.Fatal("expected the Condition to reject a mismatched ownerID")
+	}
+	if granted, _ := m.IsGranted(subject, resource, "write", Context{"ownerID": "alice"}); !granted {
+		t.Fatal("expected the Condition to accept a matching ownerID")
+	}
+}
+
+func TestPolicyManagerFallsBackWhenNoGrantDecides(t *testing.T) {
+	called := false
+	fallback := checkerFunc(func(subject Subject, resource Resource, action string, ctx Context) (bool, error) {
+		called = true
+		return true, nil
+	})
+	m := NewPolicyManager(fallback)
+
+	granted, err := m.IsGranted(Subject{ID: "alice"}, Resource{Type: "Doc"}, "read", nil)
+	if err != nil {
+		t.Fatalf("IsGranted: %v", err)
+	}
+	if !granted || !called {
+		t.Fatal("expected IsGranted to consult Fallback when no Grant decides the request")
+	}
+}
+
+func TestPolicyManagerFallbackError(t *testing.T) {
+	wantErr := errors.New("boom")
+	fallback := checkerFunc(func(subject Subject, resource Resource, action string, ctx Context) (bool, error) {
+		return false, wantErr
+	})
+	m := NewPolicyManager(fallback)
+
+	if _, err := m.IsGranted(Subject{}, Resource{}, "read", nil); err != wantErr {
+		t.Fatalf("IsGranted error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestStaticAndFileProvider(t *testing.T) {
+	grants := StaticProvider{{Role: "viewer", Type: "Doc", Actions: []string{"read"}}}
+	m := NewPolicyManager(nil)
+	if err := m.Load(grants); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	granted, err := m.IsGranted(Subject{Roles: []string{"viewer"}}, Resource{Type: "Doc"}, "read", nil)
+	if err != nil {
+		t.Fatalf("IsGranted: %v", err)
+	}
+	if !granted {
+		t.Fatal("expected the loaded StaticProvider Grant to authorize the read")
+	}
+}
+
+func TestRegisterAndLookupCondition(t *testing.T) {
+	RegisterCondition("test-always-true", func(field string, args ...interface{}) Condition {
+		return func(ctx Context) (bool, error) { return true, nil }
+	})
+
+	builder, ok := LookupCondition("test-always-true")
+	if !ok {
+		t.Fatal("expected the registered condition builder to be found")
+	}
+	cond := builder("ignored")
+	ok2, err := cond(nil)
+	if err != nil || !ok2 {
+		t.Fatalf("cond(nil) = %v, %v; want true, nil", ok2, err)
+	}
+}
+
+type checkerFunc func(subject Subject, resource Resource, action string, ctx Context) (bool, error)
+
+func (f checkerFunc) Check(subject Subject, resource Resource, action string, ctx Context) (bool, error) {
+	return f(subject, resource, action, ctx)
+}
@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// denyPolicy is the sentinel policy value meaning "deny every permission", as opposed to a concrete permission
+// name understood by permissionNames.
+const denyPolicy = "deny"
+
+var resourceBlock = regexp.MustCompile(`(?s)resource\s+"([^"]+)"\s+"([^"]+)"\s*\{\s*policy\s*=\s*"([^"]+)"\s*\}`)
+
+// ParseRules parses a minimal, HCL-flavoured rule document made of repeated resource blocks:
+//
+//	resource "com.example.Doc" "projects/foo/*" {
+//	  policy = "read"
+//	}
+//
+// policy is one of "read", "write", "create", "delete", "administration", "list" (an alias for "read") or "deny",
+// which marks every permission denied for that resource type/name rather than granted. The rules are returned as a
+// Document, which Compile turns into a Policy, same as a JSON document in CompileJSON.
+func ParseRules(rules string) (Document, error) {
+	matches := resourceBlock.FindAllStringSubmatch(rules, -1)
+	if matches == nil {
+		if strings.TrimSpace(rules) == "" {
+			return Document{}, nil
+		}
+		return Document{}, fmt.Errorf("policy: no resource blocks found in rules")
+	}
+	var doc Document
+	for _, m := range matches {
+		doc.Objects = append(doc.Objects, ObjectGrant{Type: m[1], Name: m[2], Permissions: []string{strings.ToLower(m[3])}})
+	}
+	return doc, nil
+}
+
+// CompileRules parses and compiles rules in one step.
+func CompileRules(rules string) (*Policy, error) {
+	doc, err := ParseRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(doc)
+}
+
+// NewAuthorizerFromRules compiles rules and returns an Authorizer with the result granted under the name
+// wildcardName (typically "*", matching acl.Config.WildcardName). wildcardName defaults to "*" when empty.
+// Authorize gives that name no special meaning: the compiled rules apply only to a requesting Sid whose Name()
+// equals wildcardName, not to every principal.
+func NewAuthorizerFromRules(rules string, wildcardName string) (*Authorizer, error) {
+	p, err := CompileRules(rules)
+	if err != nil {
+		return nil, err
+	}
+	if wildcardName == "" {
+		wildcardName = "*"
+	}
+	a := NewAuthorizer()
+	a.Grant(wildcardName, p)
+	return a, nil
+}
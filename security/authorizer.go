@@ -4,39 +4,70 @@ import (
 	"context"
 	"errors"
 
+	aclerrors "github.com/streamtune/acl/errors"
+	"github.com/streamtune/acl/oid"
 	"github.com/streamtune/acl/permission"
 	"github.com/streamtune/acl/sid"
 )
 
 // Acl is the interface to which all the object must complains to
 type Acl interface {
+	GetIdentity() oid.Oid
 	GetOwner() sid.Sid
 	IsGranted([]permission.Permission, []sid.Sid, bool) (bool, error)
 }
 
-// Authorizer is the default implementation of Authorizer.
+// Authorizer is the interface exposed by objects that authorize changes on an Acl.
+type Authorizer interface {
+	Authorize(ctx context.Context, acl Acl, change ChangeType) error
+}
+
+// authorizer is the default implementation of Authorizer.
 //
 // Permission will be granted if at least one of the following conditions is true for the current principal.
 // - Is the owner (as defined by ACL)
 // - Holds relevant granted authorities.
 // - Has BasePermission Adminnistration permission (as defined by the ACL).
-type Authorizer struct {
-	generalChange   *sid.Authority
-	auditingChange  *sid.Authority
-	ownershipChange *sid.Authority
+type authorizer struct {
+	generalChange   sid.Sid
+	auditingChange  sid.Sid
+	ownershipChange sid.Sid
+	evaluators      []PolicyEvaluator
 }
 
 // NewAuthorizer will create a new default AuthorizationStrategy
-func NewAuthorizer(general, auditing, ownership string) *Authorizer {
-	return &Authorizer{sid.NewAuthority(general), sid.NewAuthority(auditing), sid.NewAuthority(ownership)}
+func NewAuthorizer(general, auditing, ownership string) (Authorizer, error) {
+	a := new(authorizer)
+	var err error
+	if a.generalChange, err = sid.ForAuthority(general); err != nil {
+		return nil, err
+	}
+	if a.auditingChange, err = sid.ForAuthority(auditing); err != nil {
+		return nil, err
+	}
+	if a.ownershipChange, err = sid.ForAuthority(ownership); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// WithPolicyEvaluators reconfigures a to consult evaluators, in order, whenever its own ownership/authority/ACE
+// checks fail to grant a request. The first evaluator to return Allow or Deny short-circuits the rest of the chain;
+// if every evaluator abstains (or none are configured), Authorize keeps its original deny decision. Returns a for
+// chaining.
+func WithPolicyEvaluators(a Authorizer, evaluators ...PolicyEvaluator) Authorizer {
+	if impl, ok := a.(*authorizer); ok {
+		impl.evaluators = evaluators
+	}
+	return a
 }
 
 // Authorize perform the security check for the given change type
-func (a *Authorizer) Authorize(ctx context.Context, acl Acl, change ChangeType) error {
+func (a *authorizer) Authorize(ctx context.Context, acl Acl, change ChangeType) error {
 	if ctx == nil {
 		return errors.New("Context is required to operate on acl.")
 	}
-	sids, err := sid.NewFromContext(ctx)
+	sids, err := sid.Retrieve(ctx)
 	if err != nil {
 		return err
 	}
@@ -45,7 +76,8 @@ func (a *Authorizer) Authorize(ctx context.Context, acl Acl, change ChangeType)
 		return nil
 	}
 	// Not authorized by ACL ownership; try via administrtive permissions
-	var requiredAuthority interface{}
+	permissions := []permission.Permission{permission.AdministrationPermission}
+	var requiredAuthority sid.Sid
 	switch change {
 	case ChangeAuditing:
 		requiredAuthority = a.auditingChange
@@ -54,7 +86,13 @@ func (a *Authorizer) Authorize(ctx context.Context, acl Acl, change ChangeType)
 	case ChangeOwnership:
 		requiredAuthority = a.ownershipChange
 	default:
-		return errors.New("Unsupported change type")
+		return &aclerrors.PermissionDeniedError{
+			Accessor: currentUser,
+			Resource: acl.GetIdentity(),
+			Required: permissions[0],
+			Reason:   aclerrors.ReasonUnsupportedChange,
+			Cause:    "unsupported change type",
+		}
 	}
 	// Iterate the principal's authorities to determine right
 	for _, v := range sids {
@@ -63,10 +101,32 @@ func (a *Authorizer) Authorize(ctx context.Context, acl Acl, change ChangeType)
 		}
 	}
 	// Try to get permissions via ACEs within the ACL
-	permissions := []permission.Permission{permission.Administration}
-	if ok, err := acl.IsGranted(permissions, sids, false); err != nil && ok {
+	if ok, err := acl.IsGranted(permissions, sids, false); err == nil && ok {
 		return nil
 	}
 
-	return errors.New("Principal does not have required ACL permissions to perform required operation.")
+	// Fall through to any configured PolicyEvaluators before denying, giving external policy engines a chance to
+	// grant or deny based on context the ACL itself doesn't model.
+	for _, evaluator := range a.evaluators {
+		switch decision, err := evaluator.Evaluate(ctx, acl, sids, permissions, change); {
+		case err != nil:
+			return err
+		case decision == Allow:
+			return nil
+		case decision == Deny:
+			return &aclerrors.PermissionDeniedError{
+				Accessor: currentUser,
+				Resource: acl.GetIdentity(),
+				Required: permissions[0],
+				Reason:   aclerrors.ReasonDeniedByACL,
+			}
+		}
+	}
+
+	return &aclerrors.PermissionDeniedError{
+		Accessor: currentUser,
+		Resource: acl.GetIdentity(),
+		Required: permissions[0],
+		Reason:   aclerrors.ReasonDeniedByACL,
+	}
 }
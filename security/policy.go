@@ -0,0 +1,55 @@
+package security
+
+import (
+	"context"
+
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+// Decision is the outcome of a PolicyEvaluator's evaluation of a single authorization request.
+type Decision int
+
+const (
+	// Abstain means the evaluator has no opinion; Authorizer continues to the next evaluator in the chain, falling
+	// back to its own decision if every evaluator abstains.
+	Abstain Decision = iota
+	// Allow grants the request, short-circuiting any remaining evaluators.
+	Allow
+	// Deny refuses the request, short-circuiting any remaining evaluators.
+	Deny
+)
+
+// PolicyEvaluator is an extension point consulted by Authorizer after its own ownership/authority/ACE checks have
+// failed to grant a request, so external policy engines (Sentinel, OPA, CEL, or a hand-rolled Go closure) can grant
+// or deny access based on context the ACL itself doesn't model, such as time-of-day, source IP, or tenant flags.
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, acl Acl, sids []sid.Sid, perms []permission.Permission, change ChangeType) (Decision, error)
+}
+
+// NoopEvaluator is a PolicyEvaluator that always abstains. Configuring it behaves exactly like configuring no
+// evaluators at all, preserving Authorizer's original ownership/authority/ACE-only behavior.
+type NoopEvaluator struct{}
+
+// Evaluate always returns Abstain.
+func (NoopEvaluator) Evaluate(ctx context.Context, acl Acl, sids []sid.Sid, perms []permission.Permission, change ChangeType) (Decision, error) {
+	return Abstain, nil
+}
+
+// ScriptFunc is the signature of the closure run by a ScriptEvaluator.
+type ScriptFunc func(ctx context.Context, acl Acl, sids []sid.Sid, perms []permission.Permission, change ChangeType) (Decision, error)
+
+// ScriptEvaluator is a reference PolicyEvaluator that delegates to a user-supplied Go closure, for callers who want
+// a "code policy" extension point without standing up a full external evaluator.
+type ScriptEvaluator struct {
+	Func ScriptFunc
+}
+
+// Evaluate runs e.Func with the resolved SIDs, the target Acl (which exposes GetOwner and GetIdentity), and the
+// required permissions and change type.
+func (e ScriptEvaluator) Evaluate(ctx context.Context, acl Acl, sids []sid.Sid, perms []permission.Permission, change ChangeType) (Decision, error) {
+	if e.Func == nil {
+		return Abstain, nil
+	}
+	return e.Func(ctx, acl, sids, perms, change)
+}
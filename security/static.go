@@ -0,0 +1,72 @@
+package security
+
+import (
+	"context"
+
+	aclerrors "github.com/streamtune/acl/errors"
+	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
+	"github.com/streamtune/acl/sid"
+)
+
+// staticAcl is a fixed-decision Acl/Authorizer: it never looks at the sids or oid it is given, and so is invaluable
+// for tests, bootstrap phases, and internal system calls where ACL evaluation must be bypassed or uniformly
+// refused, mirroring Consul's allowAll/denyAll/manageAll static ACLs.
+type staticAcl struct {
+	granted bool
+	cause   string
+}
+
+func (s *staticAcl) GetIdentity() oid.Oid {
+	return nil
+}
+
+func (s *staticAcl) GetOwner() sid.Sid {
+	return nil
+}
+
+func (s *staticAcl) IsGranted([]permission.Permission, []sid.Sid, bool) (bool, error) {
+	return s.granted, nil
+}
+
+// Authorize returns nil for every ChangeType when s.granted is true, and an *aclerrors.PermissionDeniedError
+// otherwise, without touching ctx or resolving any sid.
+func (s *staticAcl) Authorize(ctx context.Context, acl Acl, change ChangeType) error {
+	if s.granted {
+		return nil
+	}
+	return &aclerrors.PermissionDeniedError{
+		Resource: acl.GetIdentity(),
+		Reason:   aclerrors.ReasonDeniedByACL,
+		Cause:    s.cause,
+	}
+}
+
+var (
+	// AllowAll is a static Acl/Authorizer that grants every permission and every ChangeType unconditionally.
+	AllowAll = &staticAcl{granted: true}
+	// DenyAll is a static Acl/Authorizer that refuses every permission and every ChangeType unconditionally.
+	DenyAll = &staticAcl{granted: false, cause: "denied by static DenyAll authorizer"}
+	// ManageAll is a static Acl/Authorizer that grants every permission and every ChangeType unconditionally,
+	// standing in for a full management/admin token.
+	ManageAll = &staticAcl{granted: true}
+)
+
+// AllowAllAcl returns the Acl singleton that grants every permission.
+func AllowAllAcl() Acl { return AllowAll }
+
+// DenyAllAcl returns the Acl singleton that refuses every permission.
+func DenyAllAcl() Acl { return DenyAll }
+
+// ManageAllAcl returns the Acl singleton that grants every permission, standing in for an admin token.
+func ManageAllAcl() Acl { return ManageAll }
+
+// AllowAllAuthorizer returns the Authorizer singleton that grants every ChangeType.
+func AllowAllAuthorizer() Authorizer { return AllowAll }
+
+// DenyAllAuthorizer returns the Authorizer singleton that refuses every ChangeType.
+func DenyAllAuthorizer() Authorizer { return DenyAll }
+
+// ManageAllAuthorizer returns the Authorizer singleton that grants every ChangeType, standing in for an admin
+// token.
+func ManageAllAuthorizer() Authorizer { return ManageAll }
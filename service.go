@@ -1,7 +1,10 @@
 package acl
 
 import (
+	"context"
+
 	"github.com/streamtune/acl/oid"
+	"github.com/streamtune/acl/permission"
 	"github.com/streamtune/acl/sid"
 )
 
@@ -15,6 +18,60 @@ type Service interface {
 
 	// Obtains all the Acl that apply for the passed in object identities and (optionally) the list of sid.
 	ReadAclsById(oids []oid.Oid, sids []sid.Sid) (map[oid.Oid]Acl, error)
+
+	// IsGrantedBatch evaluates permissions for many object identities in a single pass: implementations must perform
+	// one ReadAclsById call and walk each returned Acl at most once, memoizing decisions for Acls shared by several
+	// oids (e.g. a common parent), rather than calling ReadAclById/IsGranted once per oid. This turns the N+1 access
+	// pattern of rendering a list view of hundreds of domain objects into a single round trip.
+	//
+	// The returned maps are keyed by oid: granted reports the authorization decision, and errs carries any
+	// per-oid error (e.g. ErrNotFound) that prevented a decision from being made. A non-nil error is only returned
+	// for failures affecting the whole batch (e.g. the underlying ReadAclsById call failing).
+	IsGrantedBatch(ctx context.Context, oids []oid.Oid, perms []permission.Permission, sids []sid.Sid) (granted map[oid.Oid]bool, errs map[oid.Oid]error, err error)
+}
+
+// BulkPermissionGranter is implemented by PermissionGranter strategies that can evaluate many (Acl, oid) pairs more
+// efficiently than calling Acl.IsGranted once per entry, e.g. by memoizing shared ancestors. Service implementations
+// backing IsGrantedBatch with a custom granting strategy should type-assert for this interface and prefer it over
+// looping calls to Acl.IsGranted.
+type BulkPermissionGranter interface {
+	GrantBatch(ctx context.Context, acls map[oid.Oid]Acl, perms []permission.Permission, sids []sid.Sid) (granted map[oid.Oid]bool, errs map[oid.Oid]error)
+}
+
+// DefaultIsGrantedBatch is a reusable IsGrantedBatch implementation that Service backends can delegate to. It
+// performs a single ReadAclsById call and then walks each Acl, memoizing the decision per Acl instance returned by
+// ReadAclsById, so oids mapped to the same instance are evaluated only once. Ancestors reached through each Acl's
+// parent chain are not memoized: a parent shared by several Acls is walked once per Acl.
+func DefaultIsGrantedBatch(ctx context.Context, svc Service, oids []oid.Oid, perms []permission.Permission, sids []sid.Sid) (map[oid.Oid]bool, map[oid.Oid]error, error) {
+	acls, err := svc.ReadAclsById(oids, sids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if granter, ok := svc.(BulkPermissionGranter); ok {
+		granted, errs := granter.GrantBatch(ctx, acls, perms, sids)
+		return granted, errs, nil
+	}
+	granted := make(map[oid.Oid]bool, len(oids))
+	errs := make(map[oid.Oid]error)
+	memo := make(map[Acl]bool, len(acls))
+	for _, o := range oids {
+		a, ok := acls[o]
+		if !ok {
+			errs[o] = ErrNotFound
+			continue
+		}
+		if decision, ok := memo[a]; ok {
+			granted[o] = decision
+			continue
+		}
+		decision, err := a.IsGranted(ctx, perms, sids, false, nil)
+		if err != nil {
+			errs[o] = err
+		}
+		memo[a] = decision
+		granted[o] = decision
+	}
+	return granted, errs, nil
 }
 
 // MutableService provides support for creating and storing Acl instances.
@@ -56,7 +56,7 @@ func ForPrincipal(name string) (Sid, error) {
 	if name == "" {
 		return nil, errors.New("Cannot create Sid from an empty principal")
 	}
-	return &principal{name}, nil
+	return principal{name}, nil
 }
 
 type authority struct {
@@ -83,9 +83,41 @@ func ForAuthority(name string) (Sid, error) {
 	if name == "" {
 		return nil, errors.New("Cannot create Sid from an empty authority")
 	}
-	return &authority{name}, nil
+	return authority{name}, nil
 }
 
+// wildcard is a Sid implementation that reports itself equal to every other Sid.
+type wildcard struct {
+	name string
+}
+
+func (w wildcard) Name() string {
+	return w.name
+}
+
+func (w wildcard) Equals(Sid) bool {
+	return true
+}
+
+func (w wildcard) String() string {
+	return fmt.Sprintf("WildcardSid[%s]", w.name)
+}
+
+// Wildcard returns a Sid that matches any other Sid, useful for "everyone can X" ACEs without enumerating every
+// principal by hand. name overrides the sentinel value carried by the Sid (defaults to "*") so deployments can pick
+// one that won't collide with a legitimate principal or authority name.
+func Wildcard(name ...string) Sid {
+	n := "*"
+	if len(name) > 0 && name[0] != "" {
+		n = name[0]
+	}
+	return wildcard{n}
+}
+
+// WildcardSid is the default "*"-named wildcard Sid, for callers that just want "everyone" without picking a
+// custom sentinel name via Wildcard.
+var WildcardSid = Wildcard()
+
 type authentication interface {
 	GetPrincipal() string
 	GetAuthorities() []string
@@ -96,7 +128,7 @@ type defaultRetriever struct{}
 func (r *defaultRetriever) Retrieve(ctx context.Context) ([]Sid, error) {
 	if auth, ok := ctx.Value("Authentication").(authentication); ok {
 		authorities := auth.GetAuthorities()
-		sids := make([]Sid, len(authorities)+1)
+		sids := make([]Sid, 0, len(authorities)+1)
 		sid, err := ForPrincipal(auth.GetPrincipal())
 		if err != nil {
 			return nil, err
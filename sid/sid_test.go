@@ -0,0 +1,76 @@
+package sid
+
+import (
+	"context"
+	"testing"
+)
+
+type stubAuthentication struct {
+	principal   string
+	authorities []string
+}
+
+func (a stubAuthentication) GetPrincipal() string     { return a.principal }
+func (a stubAuthentication) GetAuthorities() []string { return a.authorities }
+
+func TestRetrieve(t *testing.T) {
+	auth := stubAuthentication{principal: "alice", authorities: []string{"ROLE_USER", "ROLE_ADMIN"}}
+	ctx := context.WithValue(context.Background(), "Authentication", auth)
+
+	sids, err := Retrieve(ctx)
+	if err != nil {
+		t.Fatalf("Retrieve: %v", err)
+	}
+
+	want := []Sid{ForPrincipalMust(t, "alice"), ForAuthorityMust(t, "ROLE_USER"), ForAuthorityMust(t, "ROLE_ADMIN")}
+	if len(sids) != len(want) {
+		t.Fatalf("Retrieve returned %d sids, want %d: %v", len(sids), len(want), sids)
+	}
+	for i, s := range sids {
+		if s == nil {
+			t.Fatalf("sids[%d] is nil, want %v", i, want[i])
+		}
+		if !s.Equals(want[i]) {
+			t.Errorf("sids[%d] = %v, want %v", i, s, want[i])
+		}
+	}
+}
+
+func TestRetrieveNoAuthorities(t *testing.T) {
+	auth := stubAuthentication{principal: "alice"}
+	ctx := context.WithValue(context.Background(), "Authentication", auth)
+
+	sids, err := Retrieve(ctx)
+	if err != nil {
+		t.Fatalf("Retrieve: %v", err)
+	}
+	if len(sids) != 1 || sids[0] == nil || !sids[0].Equals(ForPrincipalMust(t, "alice")) {
+		t.Fatalf("Retrieve with no authorities = %v, want a single non-nil PrincipalSid", sids)
+	}
+}
+
+func TestRetrieveNoAuthenticationOnContext(t *testing.T) {
+	if _, err := Retrieve(context.Background()); err == nil {
+		t.Fatal("expected Retrieve to error when the context carries no Authentication")
+	}
+}
+
+// ForPrincipalMust and ForAuthorityMust fail the test immediately instead of returning an error, for building
+// expected values inline in table-style assertions.
+func ForPrincipalMust(t *testing.T, name string) Sid {
+	t.Helper()
+	s, err := ForPrincipal(name)
+	if err != nil {
+		t.Fatalf("ForPrincipal(%q): %v", name, err)
+	}
+	return s
+}
+
+func ForAuthorityMust(t *testing.T, name string) Sid {
+	t.Helper()
+	s, err := ForAuthority(name)
+	if err != nil {
+		t.Fatalf("ForAuthority(%q): %v", name, err)
+	}
+	return s
+}
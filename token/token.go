@@ -0,0 +1,115 @@
+// Package token represents authentication tokens the way Consul's ACL system does: a token pairs a safe-to-log
+// AccessorID with an opaque SecretID that must never be logged, carries the Sids it resolves to plus an optional
+// expiration, and can be scoped Local to the datacenter/tenant that issued it.
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/streamtune/acl/sid"
+)
+
+// Token is a resolved authentication token. SecretID is the opaque credential presented by the caller and must
+// never be written to logs or audit trails; only AccessorID is safe to log, mirroring the accessor/secret split
+// used by Consul and Vault. Local tokens are only valid in the datacenter/tenant that issued them.
+type Token struct {
+	AccessorID     string
+	SecretID       string
+	Sids           []sid.Sid
+	Local          bool
+	ExpirationTime *time.Time
+}
+
+// String renders the token for logging, always redacting SecretID.
+func (t *Token) String() string {
+	return fmt.Sprintf("Token[accessor: %s, local: %t]", t.AccessorID, t.Local)
+}
+
+// Expired reports whether t has a set ExpirationTime that has already passed.
+func (t *Token) Expired() bool {
+	return t.ExpirationTime != nil && t.ExpirationTime.Before(time.Now())
+}
+
+// TokenResolver exchanges a presented secretID for the Token it was issued to.
+type TokenResolver interface {
+	Resolve(ctx context.Context, secretID string) (*Token, error)
+}
+
+// ErrExpired is returned when a resolved Token's ExpirationTime has already passed.
+var ErrExpired = errors.New("token: token has expired")
+
+// ErrWrongDatacenter is returned when a Local token is presented outside the datacenter/tenant that issued it.
+var ErrWrongDatacenter = errors.New("token: local token is not valid outside its issuing datacenter")
+
+// SidRetrievalStrategy adapts a TokenResolver into the []sid.Sid slice Acl.IsGranted consumes, implementing
+// sid.Retriever so it can be installed as sid.DefaultRetriever.
+type SidRetrievalStrategy struct {
+	// Resolver exchanges the secret carried by a request's context for the Token it was issued to.
+	Resolver TokenResolver
+	// Datacenter is the local datacenter/tenant this process belongs to. Empty disables Local enforcement.
+	Datacenter string
+}
+
+// NewSidRetrievalStrategy creates a SidRetrievalStrategy resolving secrets through resolver, rejecting Local tokens
+// presented outside datacenter. Pass an empty datacenter to disable that check.
+func NewSidRetrievalStrategy(resolver TokenResolver, datacenter string) *SidRetrievalStrategy {
+	return &SidRetrievalStrategy{Resolver: resolver, Datacenter: datacenter}
+}
+
+// Retrieve resolves the secret carried by ctx (under the "Secret" key) and returns the Sids it maps to, rejecting
+// expired tokens and Local tokens presented outside s.Datacenter.
+func (s *SidRetrievalStrategy) Retrieve(ctx context.Context) ([]sid.Sid, error) {
+	secret, ok := ctx.Value("Secret").(string)
+	if !ok {
+		return nil, errors.New("token: no secret found on context")
+	}
+	t, err := s.Resolver.Resolve(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+	if t.Expired() {
+		return nil, ErrExpired
+	}
+	if t.Local && s.Datacenter != "" {
+		dc, _ := ctx.Value("Datacenter").(string)
+		if dc != s.Datacenter {
+			return nil, ErrWrongDatacenter
+		}
+	}
+	return t.Sids, nil
+}
+
+// BootstrapResetPath is the path AllowBootstrapReset checks, mirroring Consul's acl-bootstrap-reset file: an
+// operator who has lost every management token can write the current reset index there to re-enable exactly one
+// more bootstrap, without wiping and recreating every ACL from scratch.
+var BootstrapResetPath = "acl-bootstrap-reset"
+
+// AllowBootstrapReset reports whether a sentinel file exists at BootstrapResetPath containing exactly resetIndex.
+// The caller is responsible for deleting the file once the reset has been consumed, so each sentinel only ever
+// permits a single bootstrap.
+func AllowBootstrapReset(resetIndex uint64) (bool, error) {
+	data, err := os.ReadFile(BootstrapResetPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	written, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("token: malformed bootstrap-reset sentinel: %w", err)
+	}
+	return written == resetIndex, nil
+}
+
+// WriteBootstrapReset writes resetIndex to BootstrapResetPath so the next AllowBootstrapReset call for that same
+// index succeeds.
+func WriteBootstrapReset(resetIndex uint64) error {
+	return os.WriteFile(BootstrapResetPath, []byte(strconv.FormatUint(resetIndex, 10)), 0600)
+}
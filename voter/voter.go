@@ -0,0 +1,184 @@
+// Package voter provides an AclEntryVoter modelled on Spring Security's AclEntryVoter: given a domain object found
+// among a method's arguments, it resolves the object's Acl and votes on whether the current principal holds one of
+// a configured set of required Permissions against it.
+package voter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/streamtune/acl"
+)
+
+// Vote is the outcome of a single authorization decision, mirroring Spring Security's AccessDecisionVoter
+// constants.
+type Vote int
+
+// Granted means the voter is affirmatively satisfied that auth holds a required permission.
+// Denied means the voter considered the request and found no required permission was held.
+// Abstain means the voter had no opinion - either attribute wasn't the one it processes, or no domain object
+// argument matching ProcessDomainObjectClass could be found and WithAbstainOnNil was set.
+const (
+	Abstain Vote = iota
+	Granted
+	Denied
+)
+
+func (v Vote) String() string {
+	switch v {
+	case Granted:
+		return "Granted"
+	case Denied:
+		return "Denied"
+	default:
+		return "Abstain"
+	}
+}
+
+// AclService loads the Acl for an object identity, as required by AclEntryVoter. It is satisfied by
+// domain.MutableService-style backends that can resolve a single identity.
+type AclService interface {
+	ReadAclById(oid acl.Oid, sids []acl.Sid) (acl.Instance, error)
+}
+
+// ObjectIdentityRetrievalStrategy resolves the acl.Oid for a domain object instance found among a method's
+// arguments.
+type ObjectIdentityRetrievalStrategy interface {
+	GetObjectIdentity(domainObject interface{}) (acl.Oid, error)
+}
+
+// AclEntryVoter inspects the arguments of an authorized call for an instance of ProcessDomainObjectClass, loads its
+// Acl through Service, and votes Granted/Denied/Abstain based on whether the current principal holds one of
+// RequiredPermissions against it.
+type AclEntryVoter struct {
+	service     AclService
+	oidStrategy ObjectIdentityRetrievalStrategy
+	sidStrategy acl.SidRetrievalStrategy
+
+	processConfigAttribute   string
+	processDomainObjectClass reflect.Type
+	requiredPermissions      []acl.Permission
+
+	// propertyPath, when non-empty, is navigated on the matched argument (e.g. "owner.company") before resolving
+	// the Acl, so a vote can be cast against a related object instead of the argument itself.
+	propertyPath string
+
+	// abstainOnNil reports Abstain, rather than Denied, when no argument matches ProcessDomainObjectClass or the
+	// matched argument is nil. Spring's AclEntryVoter defaults to denying; callers that want the permissive default
+	// should set this.
+	abstainOnNil bool
+}
+
+// NewAclEntryVoter creates an AclEntryVoter that processes attribute, looking among a call's arguments for one
+// whose runtime type is class and checking it against perms.
+func NewAclEntryVoter(service AclService, oidStrategy ObjectIdentityRetrievalStrategy, sidStrategy acl.SidRetrievalStrategy, attribute string, class reflect.Type, perms []acl.Permission) *AclEntryVoter {
+	return &AclEntryVoter{
+		service:                  service,
+		oidStrategy:              oidStrategy,
+		sidStrategy:              sidStrategy,
+		processConfigAttribute:   attribute,
+		processDomainObjectClass: class,
+		requiredPermissions:      perms,
+	}
+}
+
+// WithPropertyPath attaches a dotted property path that is navigated on the matched argument before resolving the
+// Acl, e.g. "owner.company" to authorize against a related object. Returns v for chaining.
+func (v *AclEntryVoter) WithPropertyPath(path string) *AclEntryVoter {
+	v.propertyPath = path
+	return v
+}
+
+// WithAbstainOnNil makes Vote return Abstain, instead of Denied, whenever no matching argument is found or it is
+// nil. Returns v for chaining.
+func (v *AclEntryVoter) WithAbstainOnNil() *AclEntryVoter {
+	v.abstainOnNil = true
+	return v
+}
+
+// Vote decides whether auth holds one of v.requiredPermissions against the domain object found among args.
+// Abstain is returned when attribute isn't the one v processes. When no argument matching ProcessDomainObjectClass
+// is found, or PropertyPath navigation ends at nil, Vote returns Denied, or Abstain if WithAbstainOnNil was set.
+func (v *AclEntryVoter) Vote(auth acl.Authentication, attribute string, args ...interface{}) (Vote, error) {
+	if attribute != v.processConfigAttribute {
+		return Abstain, nil
+	}
+	target := v.findArgument(args)
+	if target == nil {
+		if v.abstainOnNil {
+			return Abstain, nil
+		}
+		return Denied, nil
+	}
+	if v.propertyPath != "" {
+		resolved, err := navigate(target, v.propertyPath)
+		if err != nil {
+			return Denied, err
+		}
+		if resolved == nil {
+			if v.abstainOnNil {
+				return Abstain, nil
+			}
+			return Denied, nil
+		}
+		target = resolved
+	}
+	identity, err := v.oidStrategy.GetObjectIdentity(target)
+	if err != nil {
+		return Denied, err
+	}
+	sids := v.sidStrategy.GetSids(auth)
+	instance, err := v.service.ReadAclById(identity, sids)
+	if err != nil {
+		return Denied, err
+	}
+	granted, err := instance.IsGranted(v.requiredPermissions, sids, false)
+	if err != nil {
+		return Denied, err
+	}
+	if granted {
+		return Granted, nil
+	}
+	return Denied, nil
+}
+
+// findArgument returns the first non-nil element of args whose runtime type is v.processDomainObjectClass, or nil
+// if none match.
+func (v *AclEntryVoter) findArgument(args []interface{}) interface{} {
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		if reflect.TypeOf(arg) == v.processDomainObjectClass {
+			return arg
+		}
+	}
+	return nil
+}
+
+// navigate walks path, a dot-separated chain of exported field names (e.g. "owner.company"), starting from obj. It
+// transparently dereferences pointers, and returns a nil interface if a pointer along the chain is nil.
+func navigate(obj interface{}, path string) (interface{}, error) {
+	value := reflect.ValueOf(obj)
+	for _, field := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil, nil
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("voter: cannot navigate property %q on kind %s", field, value.Kind())
+		}
+		name := strings.ToUpper(field[:1]) + field[1:]
+		value = value.FieldByName(name)
+		if !value.IsValid() {
+			return nil, fmt.Errorf("voter: type %s has no exported field %q", reflect.TypeOf(obj), name)
+		}
+	}
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, nil
+	}
+	return value.Interface(), nil
+}
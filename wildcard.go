@@ -0,0 +1,94 @@
+package acl
+
+import "fmt"
+
+// Config carries tunables that let integrators adapt ACL evaluation to their deployment without forking the
+// library. It is threaded through NewCheckerWithConfig, a legacy Acl's WithConfig and
+// domain.NewDefaultPermissionGrantingStrategyWithConfig so a deployment that needs a different sentinel (e.g.
+// "anonymous" instead of "*") can override it without patching the library.
+type Config struct {
+	// WildcardName is the Sid name that a Checker built by NewCheckerWithConfig treats as the wildcard Sid: an Ace
+	// whose Sid has this name matches every requesting Sid. MatchAce does not read it; it recognises the Sid
+	// returned by Wildcard by its type, whatever its name. Defaults to "*".
+	WildcardName string
+
+	// WildcardPermission is the Permission that, when bound to an Ace, matches every requested Permission. Defaults
+	// to AnyPermission (all bits set), but can be overridden to a sentinel bit for deployments that want wildcard
+	// grants to remain distinguishable from "happens to have every flag set".
+	WildcardPermission Permission
+}
+
+// DefaultConfig returns a Config initialized with the library's default settings.
+func DefaultConfig() *Config {
+	return &Config{WildcardName: "*", WildcardPermission: AnyPermission}
+}
+
+// AnyPermission is a Permission that matches every requested permission bit. Binding an Ace to AnyPermission grants
+// (or denies, if the Ace is non-granting) the bound Sid regardless of which Permission was actually requested.
+const AnyPermission Permission = ^Permission(0)
+
+// wildcardSid is a Sid implementation that reports itself equal to every other Sid.
+type wildcardSid struct {
+	name string
+}
+
+// Wildcard returns a Sid that matches any other Sid. Binding an Ace to it grants (or denies) every principal,
+// regardless of the concrete Sid being checked. name is the sentinel name carried by the Sid (see
+// Config.WildcardName) and defaults to "*" when empty, so deployments can pick a value that won't collide with a
+// legitimate authority name in their identity provider.
+func Wildcard(name string) Sid {
+	if name == "" {
+		name = "*"
+	}
+	return wildcardSid{name}
+}
+
+// Equals always returns true: a wildcardSid matches every Sid.
+func (w wildcardSid) Equals(Sid) bool {
+	return true
+}
+
+func (w wildcardSid) String() string {
+	return fmt.Sprintf("WildcardSid[%s]", w.name)
+}
+
+// MatchableAce is the minimal Ace shape MatchAce needs: enough to decide whether an ace matches a requested
+// Permission/Sid pair, independent of whichever richer Ace variant (LegacyAce or InstanceAce) the caller uses.
+type MatchableAce interface {
+	GetPermission() Permission
+	GetSid() Sid
+	IsGranting() bool
+}
+
+// MatchAce scans aces for the entry that decides p for sid, preferring an exact Sid match over one reached only
+// through cfg's wildcard Sid/Permission (see Wildcard/AnyPermission and Config.WildcardPermission). The bool result
+// reports whether the match was only a wildcard one, so the caller can let a later, more specific ace still win
+// (least-specific-loses). cfg may be nil, in which case AnyPermission is used as the wildcard permission.
+//
+// Shared by the legacy DefaultPermissionGranter (domain.go) and domain.DefaultPermissionGrantingStrategy, which both
+// operate on this package's bare Sid/Permission types; Checker keeps its own variant since it matches over the
+// sid/permission subpackages' distinct types instead.
+func MatchAce[A MatchableAce](aces []A, p Permission, s Sid, cfg *Config) (A, bool) {
+	wildcardPermission := AnyPermission
+	if cfg != nil {
+		wildcardPermission = cfg.WildcardPermission
+	}
+	var wildcardMatch A
+	found := false
+	for _, ace := range aces {
+		if !ace.GetPermission().Match(p) && ace.GetPermission() != wildcardPermission {
+			continue
+		}
+		if _, ok := ace.GetSid().(wildcardSid); ok {
+			if !found {
+				wildcardMatch = ace
+				found = true
+			}
+			continue
+		}
+		if ace.GetSid().Equals(s) {
+			return ace, false
+		}
+	}
+	return wildcardMatch, found
+}